@@ -0,0 +1,530 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobuzz/pkg/client"
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/storage/manifest"
+)
+
+// tagList collects repeated -tag flags.
+type tagList []string
+
+func (t *tagList) String() string     { return strings.Join(*t, ",") }
+func (t *tagList) Set(v string) error { *t = append(*t, v); return nil }
+
+// definitionFlags define fetch by file or by single fields.
+type definitionFlags struct {
+	file     string
+	name     string
+	url      string
+	interval int
+	tags     tagList
+}
+
+func (d *definitionFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&d.file, "f", "", "fetch definition file, JSON or YAML, - for stdin")
+	fs.StringVar(&d.name, "name", "", "fetch name")
+	fs.StringVar(&d.url, "url", "", "fetched URL")
+	fs.IntVar(&d.interval, "interval", 0, "seconds between fetches")
+	fs.Var(&d.tags, "tag", "fetch tag, repeatable")
+}
+
+// apply sets fields of fetch given by flags set on command line.
+func (d *definitionFlags) apply(fs *flag.FlagSet, f *adding.Fetch) {
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "name":
+			f.Name = d.name
+		case "url":
+			f.URL = d.url
+		case "interval":
+			f.Interval = d.interval
+		case "tag":
+			f.Tags = d.tags
+		}
+	})
+}
+
+// readInput reads file at path, stdin for "-".
+func (e *env) readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(e.stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// readDefinition reads fetch definition from JSON or YAML file.
+func (e *env) readDefinition(path string) (adding.Fetch, error) {
+	var f adding.Fetch
+	data, err := e.readInput(path)
+	if err != nil {
+		return f, err
+	}
+	if isYAML(path) {
+		if data, err = manifest.ToJSON(data); err != nil {
+			return f, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return f, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// isYAML reports whether file at path is YAML by its extension.
+func isYAML(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// parseTime parses time flag given as RFC3339 or Unix seconds, empty
+// value is zero time.
+func parseTime(name, v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if sec, err := strconv.ParseFloat(v, 64); err == nil {
+		return time.Unix(0, int64(sec*float64(time.Second))), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return t, fmt.Errorf("%s must be RFC3339 time or Unix seconds, got %q", name, v)
+	}
+	return t, nil
+}
+
+func runCreate(e *env, args []string) error {
+	fs, output := e.flags("create")
+	var d definitionFlags
+	d.register(fs)
+	dedup := fs.Bool("dedup", false, "return fetch of the same URL if it exists")
+	now := fs.Bool("now", false, "run the first fetch at once")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	var f adding.Fetch
+	if d.file != "" {
+		if f, err = e.readDefinition(d.file); err != nil {
+			return err
+		}
+	}
+	d.apply(fs, &f)
+
+	id, isNew, err := e.client.CreateFetchWith(e.ctx, f, client.CreateOptions{Dedup: *dedup, RunNow: *now})
+	if err != nil {
+		return err
+	}
+	created := struct {
+		ID       string `json:"id"`
+		Existing bool   `json:"existing,omitempty"`
+	}{ID: id, Existing: !isNew}
+	return p.print(created, []string{"ID"}, func() [][]string {
+		return [][]string{{created.ID}}
+	})
+}
+
+func runList(e *env, args []string) error {
+	fs, output := e.flags("list")
+	tag := fs.String("tag", "", "fetches having tag")
+	substr := fs.String("url", "", "fetches with URL containing substring")
+	limit := fs.Int("limit", 0, "at most this many fetches, 0 for all")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	opts := client.ListOptions{Tag: *tag, URL: *substr, Limit: listing.MaxLimit}
+	if *limit > 0 && *limit < listing.MaxLimit {
+		opts.Limit = *limit
+	}
+
+	fetches := []listing.Fetch{}
+	for {
+		page, next, err := e.client.Fetches(e.ctx, opts)
+		if err != nil {
+			return err
+		}
+		fetches = append(fetches, page...)
+
+		if next == "" || *limit > 0 && len(fetches) >= *limit {
+			break
+		}
+		opts.Cursor = next
+	}
+	if *limit > 0 && len(fetches) > *limit {
+		fetches = fetches[:*limit]
+	}
+
+	return p.print(fetches, fetchHeader, func() [][]string {
+		rows := make([][]string, len(fetches))
+		for i, f := range fetches {
+			rows[i] = fetchRow(f)
+		}
+		return rows
+	})
+}
+
+func runGet(e *env, args []string) error {
+	fs, output := e.flags("get")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id := pos[0]
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	f, err := e.client.Fetch(e.ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.print(f, fetchHeader, func() [][]string { return [][]string{fetchRow(f)} })
+}
+
+func runUpdate(e *env, args []string) error {
+	fs, output := e.flags("update")
+	var d definitionFlags
+	d.register(fs)
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id := pos[0]
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	var f adding.Fetch
+	if d.file != "" {
+		if f, err = e.readDefinition(d.file); err != nil {
+			return err
+		}
+	} else { // flags change stored definition
+		stored, err := e.client.Fetch(e.ctx, id)
+		if err != nil {
+			return err
+		}
+		f = adding.Fetch{
+			Name:       stored.Name,
+			URL:        stored.URL,
+			Interval:   stored.Interval,
+			Assertions: stored.Assertions,
+			Extractors: stored.Extractors,
+			Retention:  stored.Retention,
+			Tags:       stored.Tags,
+		}
+	}
+	d.apply(fs, &f)
+
+	updated, err := e.client.UpdateFetch(e.ctx, id, f)
+	if err != nil {
+		return err
+	}
+	return p.print(updated, fetchHeader, func() [][]string { return [][]string{fetchRow(updated)} })
+}
+
+func runDelete(e *env, args []string) error {
+	fs, _ := e.flags("delete")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id := pos[0]
+
+	if err := e.client.DeleteFetch(e.ctx, id); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "Fetch %s deleted.\n", id)
+	return nil
+}
+
+func runPause(e *env, args []string) error {
+	fs, output := e.flags("pause")
+	until := fs.String("until", "", "pause end, RFC3339 or Unix seconds")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	end, err := parseTime("until", *until)
+	if err != nil {
+		return err
+	}
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	f, err := e.client.PauseFetch(e.ctx, pos[0], end)
+	if err != nil {
+		return err
+	}
+	return p.print(f, fetchHeader, func() [][]string { return [][]string{fetchRow(f)} })
+}
+
+func runResume(e *env, args []string) error {
+	fs, output := e.flags("resume")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	f, err := e.client.ResumeFetch(e.ctx, pos[0])
+	if err != nil {
+		return err
+	}
+	return p.print(f, fetchHeader, func() [][]string { return [][]string{fetchRow(f)} })
+}
+
+func runRun(e *env, args []string) error {
+	fs, output := e.flags("run")
+	timeout := fs.Duration("timeout", 0, "wait for the response this long, server default if 0")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	h, err := e.client.RunFetch(e.ctx, pos[0], *timeout)
+	if err != nil {
+		return err
+	}
+	return p.print(h, historyHeader, func() [][]string { return [][]string{historyRow(h)} })
+}
+
+func runTest(e *env, args []string) error {
+	fs, output := e.flags("test")
+	var d definitionFlags
+	d.register(fs)
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	var f adding.Fetch
+	if d.file != "" {
+		if f, err = e.readDefinition(d.file); err != nil {
+			return err
+		}
+	}
+	d.apply(fs, &f)
+
+	r, err := e.client.TryFetch(e.ctx, f)
+	if err != nil {
+		return err
+	}
+	return p.print(r, tryHeader, func() [][]string { return [][]string{tryRow(r)} })
+}
+
+func runHistory(e *env, args []string) error {
+	fs, output := e.flags("history")
+	limit := fs.Int("limit", 20, "at most this many responses")
+	outcome := fs.String("outcome", "", "responses with outcome: ok, failed or error")
+	order := fs.String("order", "desc", "asc for oldest first, desc for newest first")
+	from := fs.String("from", "", "responses created since, RFC3339 or Unix seconds")
+	to := fs.String("to", "", "responses created until, RFC3339 or Unix seconds")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id := pos[0]
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	opts := client.HistoryOptions{Outcome: *outcome, Limit: *limit}
+	switch *order {
+	case "asc":
+	case "desc":
+		opts.Desc = true
+	default:
+		return fmt.Errorf("order must be asc or desc, got %q", *order)
+	}
+	if opts.From, err = parseTime("from", *from); err != nil {
+		return err
+	}
+	if opts.To, err = parseTime("to", *to); err != nil {
+		return err
+	}
+
+	history, _, err := e.client.History(e.ctx, id, opts)
+	if err != nil {
+		return err
+	}
+	return p.print(history, historyHeader, func() [][]string {
+		rows := make([][]string, len(history))
+		for i, h := range history {
+			rows[i] = historyRow(h)
+		}
+		return rows
+	})
+}
+
+func runTail(e *env, args []string) error {
+	fs, output := e.flags("tail")
+	n := fs.Int("n", 10, "number of last responses shown first")
+	every := fs.Duration("every", 5*time.Second, "polling period")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id := pos[0]
+	if _, err := newPrinter(e.stdout, *output); err != nil {
+		return err
+	}
+
+	if *output == formatTable {
+		fmt.Fprintln(e.stdout, strings.Join(historyHeader, "\t"))
+	}
+	err = e.client.Follow(e.ctx, id, *n, *every, func(r client.Response) error {
+		return e.printTailed(*output, r)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// printTailed writes single followed response as table row, JSON line
+// or YAML document.
+func (e *env) printTailed(format string, h client.Response) error {
+	switch format {
+	case formatJSON:
+		data, err := json.Marshal(h)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(e.stdout, "%s\n", data)
+		return err
+	case formatYAML:
+		data, err := toYAML(h)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(e.stdout, "---\n%s", data)
+		return err
+	}
+	_, err := fmt.Fprintln(e.stdout, strings.Join(historyRow(h), "\t"))
+	return err
+}
+
+func runExport(e *env, args []string) error {
+	fs, _ := e.flags("export")
+	format := fs.String("format", "json", "json array or ndjson")
+	file := fs.String("file", "-", "output file, - for stdout")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	media := client.MediaJSON
+	switch *format {
+	case "json":
+	case "ndjson":
+		media = client.MediaNDJSON
+	default:
+		return fmt.Errorf("format must be json or ndjson, got %q", *format)
+	}
+
+	w := e.stdout
+	if *file != "-" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return e.client.ExportFetches(e.ctx, w, media)
+}
+
+func runImport(e *env, args []string) error {
+	fs, output := e.flags("import")
+	atomic := fs.Bool("atomic", false, "create nothing unless all definitions are valid")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	p, err := newPrinter(e.stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	path := pos[0]
+	data, err := e.readInput(path)
+	if err != nil {
+		return err
+	}
+	media := client.MediaJSON
+	switch {
+	case isYAML(path):
+		if data, err = manifest.ToJSON(data); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case filepath.Ext(path) == ".ndjson", filepath.Ext(path) == ".jsonl":
+		media = client.MediaNDJSON
+	}
+
+	res, err := e.client.ImportFetches(e.ctx, data, media, *atomic)
+	if err != nil {
+		return err
+	}
+
+	err = p.print(res, []string{"INDEX", "ID", "ERROR"}, func() [][]string {
+		rows := make([][]string, len(res.Results))
+		for i, r := range res.Results {
+			rows[i] = []string{fmt.Sprint(r.Index), "-", "-"}
+			if r.ID != "" {
+				rows[i][1] = r.ID
+			}
+			if r.Error != nil {
+				rows[i][2] = r.Error.Error()
+			}
+		}
+		return rows
+	})
+	if err != nil {
+		return err
+	}
+	if res.Failed > 0 {
+		return fmt.Errorf("%d of %d fetch definitions failed", res.Failed, res.Failed+res.Created)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultServer is used when server URL is not configured.
+const DefaultServer = "http://127.0.0.1:8080"
+
+// config defines connection to the server and default output format.
+// Flags take precedence over environment, environment over file.
+type config struct {
+	Server string `yaml:"server"`
+	APIKey string `yaml:"api_key"`
+	Output string `yaml:"output"`
+}
+
+// defaultConfigPath returns path of config file in user config
+// directory, e.g. ~/.config/gobuzz/config.yaml.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gobuzz", "config.yaml")
+}
+
+// loadConfig reads config file at path and applies GOBUZZ_SERVER and
+// GOBUZZ_API_KEY environment variables. Missing file is an error only
+// if it was asked for explicitly.
+func loadConfig(path string, explicit bool) (config, error) {
+	cfg := config{Server: DefaultServer, Output: formatTable}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("reading config %s: %w", path, err)
+		}
+	case path == "", errors.Is(err, fs.ErrNotExist) && !explicit:
+	default:
+		return cfg, fmt.Errorf("reading config: %w", err)
+	}
+
+	if v := os.Getenv("GOBUZZ_SERVER"); v != "" {
+		cfg.Server = v
+	}
+	if v := os.Getenv("GOBUZZ_API_KEY"); v != "" {
+		cfg.APIKey = v
+	}
+	return cfg, nil
+}
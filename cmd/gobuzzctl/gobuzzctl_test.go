@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+	"github.com/gobuzz/pkg/http/rest"
+	"github.com/gobuzz/pkg/storage/memory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("gobuzzctl", func() {
+	var (
+		server *httptest.Server
+		respsr responding.Service
+		secret string
+		dir    string
+	)
+
+	BeforeEach(func() { // Configuration
+		s := new(memory.ResponseFetch)
+		auth := authenticating.NewService(&s.Keys)
+		_, secret, _ = auth.CreateKey("ci", "", []string{authenticating.ScopeAdmin})
+		respsr = responding.NewService(&s.Responses)
+		server = httptest.NewServer(rest.ServHandler(rest.Services{ // Creation
+			Adder:     adding.NewService(&s.Fetches, &s.Tenants),
+			Responder: respsr,
+			Lister:    listing.NewService(&s.Responses, &s.Fetches),
+			Compactor: compacting.NewService(s, compacting.Policy{}),
+			Auth:      auth,
+		}))
+
+		var err error
+		dir, err = os.MkdirTemp("", "gobuzzctl")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	// ctl runs gobuzzctl with args against test server and returns its
+	// output.
+	ctl := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		args = append([]string{"-config", "", "-server", server.URL, "-key", secret}, args...)
+		err := run(context.Background(), args, strings.NewReader(""), &out)
+		return out.String(), err
+	}
+
+	// file writes file into test directory and returns its path.
+	file := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	Context("When managing a single fetch.", func() {
+		It("Should create, get, update and delete it.", func() {
+			out, err := ctl("create", "-name", "front", "-url", "https://httpbin.org/range/15", "-interval", "60", "-tag", "prod", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+			var created struct{ ID string }
+			Expect(json.Unmarshal([]byte(out), &created)).To(Succeed())
+
+			out, err = ctl("get", created.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchRegexp(`ID\s+NAME\s+URL\s+INTERVAL\s+TAGS\s+STATE\s+CREATED\n` + created.ID + `\s+front\s+https://httpbin.org/range/15\s+60s\s+prod\s+active\s+`))
+
+			out, err = ctl("update", "front", "-interval", "30", "-o", "yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("interval: 30\n"))
+			Expect(out).To(ContainSubstring("name: front\n"))
+
+			out, err = ctl("pause", "front", "-until", "2100-01-01T00:00:00Z", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring(`"paused": true`))
+			Expect(out).To(ContainSubstring(`"paused_until": "2100-01-01T00:00:00Z"`))
+
+			out, err = ctl("resume", "front")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring(" active "))
+
+			out, err = ctl("delete", "front")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("Fetch front deleted.\n"))
+
+			_, err = ctl("get", "front")
+			Expect(err).To(MatchError(`Fetch "front" not found. (not_found)`))
+		})
+
+		It("Should read definition from YAML file.", func() {
+			path := file("fetch.yaml", "name: slow\nurl: https://httpbin.org/delay/3\ninterval: 120\nassertions:\n  - type: contains\n    value: abc\n")
+			_, err := ctl("create", "-f", path, "-interval", "90")
+			Expect(err).NotTo(HaveOccurred())
+
+			out, err := ctl("get", "slow", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+			var f listing.Fetch
+			Expect(json.Unmarshal([]byte(out), &f)).To(Succeed())
+			Expect(f.Name).To(Equal("slow"))
+			Expect(f.Interval).To(Equal(90))
+			Expect(f.Assertions).To(Equal([]adding.Check{{Type: adding.CheckContains, Value: "abc"}}))
+		})
+
+		It("Should find fetch of the same URL with dedup.", func() {
+			first, err := ctl("create", "-url", "https://httpbin.org/range/15", "-interval", "3600", "-dedup")
+			Expect(err).NotTo(HaveOccurred())
+
+			again, err := ctl("create", "-url", "https://httpbin.org/range/15", "-interval", "60", "-dedup", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again).To(MatchJSON(`{"id":"` + strings.Fields(first)[1] + `","existing":true}`))
+		})
+
+		It("Should report API and usage errors.", func() {
+			_, err := ctl("create", "-url", "woops", "-interval", "60")
+			Expect(err).To(MatchError("URL path is not accepted. (validation_failed, field url)"))
+
+			_, err = ctl("get", "a", "b")
+			Expect(err).To(MatchError("get expects 1 argument(s), got 2"))
+
+			_, err = ctl("list", "-o", "xml")
+			Expect(err).To(MatchError(`unknown output format "xml", table, json or yaml expected`))
+
+			_, err = ctl("run", "missing", "-timeout", "1s")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HaveSuffix("(not_found)"))
+
+			_, err = ctl("test", "-url", "woops", "-interval", "60")
+			Expect(err).To(MatchError("URL path is not accepted. (validation_failed, field url)"))
+
+			_, err = ctl("woops")
+			Expect(err).To(MatchError(`unknown command "woops"`))
+		})
+	})
+
+	Context("When importing and exporting fetches.", func() {
+		It("Should round trip definitions and list them across pages.", func() {
+			path := file("fetches.ndjson", `{"url":"https://httpbin.org/range/15","interval":60,"tags":["prod"]}
+{"url":"https://httpbin.org/range/16","interval":60}
+{"url":"https://httpbin.org/range/17","interval":60,"tags":["prod"]}
+`)
+			out, err := ctl("import", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchRegexp(`INDEX\s+ID\s+ERROR\n0\s+\w{26}\s+-\n1\s+\w{26}\s+-\n2\s+\w{26}\s+-\n`))
+
+			out, err = ctl("list", "-tag", "prod", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+			var fetches []listing.Fetch
+			Expect(json.Unmarshal([]byte(out), &fetches)).To(Succeed())
+			Expect(fetches).To(HaveLen(2))
+
+			out, err = ctl("list", "-limit", "1", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Unmarshal([]byte(out), &fetches)).To(Succeed())
+			Expect(fetches).To(HaveLen(1))
+
+			exported := filepath.Join(dir, "export.json")
+			_, err = ctl("export", "-file", exported)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := os.ReadFile(exported)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Unmarshal(data, &fetches)).To(Succeed())
+			Expect(fetches).To(HaveLen(3))
+		})
+
+		It("Should report failed definitions.", func() {
+			path := file("fetches.yaml", "- url: https://httpbin.org/range/15\n  interval: 60\n- url: woops\n  interval: 60\n")
+			out, err := ctl("import", "-atomic", path, "-o", "json")
+			Expect(err).To(MatchError("2 of 2 fetch definitions failed"))
+			Expect(out).To(ContainSubstring(`"failed": 2`))
+		})
+	})
+
+	Context("When following history.", func() {
+		It("Should print new responses until interrupted.", func() {
+			created, err := ctl("create", "-name", "front", "-url", "https://httpbin.org/range/15", "-interval", "3600", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+			var f listing.Fetch
+			Expect(json.Unmarshal([]byte(created), &f)).To(Succeed())
+			respsr.CreateRecord(responding.Response{StorageKeyID: f.ID, Tenant: adding.DefaultTenant, Content: "abc", Duration: 0.1})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			out := new(safeBuffer)
+			done := make(chan error)
+			go func() {
+				args := []string{"-config", "", "-server", server.URL, "-key", secret, "tail", "front", "-every", "10ms", "-o", "json"}
+				done <- run(ctx, args, strings.NewReader(""), out)
+			}()
+
+			Eventually(out.String).Should(ContainSubstring(`"response":"abc"`))
+			time.Sleep(10 * time.Millisecond) // next response must be newer
+			respsr.CreateRecord(responding.Response{StorageKeyID: f.ID, Tenant: adding.DefaultTenant, Content: "def", Duration: 0.2})
+			Eventually(out.String).Should(ContainSubstring(`"response":"def"`))
+
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+			Expect(strings.Count(out.String(), "\n")).To(Equal(2))
+		})
+
+		It("Should wait for the first response of new fetch.", func() {
+			created, err := ctl("create", "-name", "front", "-url", "https://httpbin.org/range/15", "-interval", "3600", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+			var f listing.Fetch
+			Expect(json.Unmarshal([]byte(created), &f)).To(Succeed())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			out := new(safeBuffer)
+			done := make(chan error)
+			go func() {
+				args := []string{"-config", "", "-server", server.URL, "-key", secret, "tail", "front", "-every", "10ms", "-o", "json"}
+				done <- run(ctx, args, strings.NewReader(""), out)
+			}()
+
+			Consistently(done, "50ms").ShouldNot(Receive())
+			respsr.CreateRecord(responding.Response{StorageKeyID: f.ID, Tenant: adding.DefaultTenant, Content: "abc", Duration: 0.1})
+			Eventually(out.String).Should(ContainSubstring(`"response":"abc"`))
+
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+
+			_, err = ctl("tail", "missing")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When reading config file.", func() {
+		It("Should take server and key from it unless set by flags.", func() {
+			path := file("config.yaml", "server: "+server.URL+"\napi_key: "+secret+"\noutput: json\n")
+			var out bytes.Buffer
+			Expect(run(context.Background(), []string{"-config", path, "list"}, nil, &out)).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`[]`))
+
+			err := run(context.Background(), []string{"-config", path, "-key", "woops", "list"}, nil, &out)
+			Expect(err).To(MatchError("Missing or invalid API key. (unauthorized)"))
+
+			err = run(context.Background(), []string{"-config", filepath.Join(dir, "missing.yaml"), "list"}, nil, &out)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+// safeBuffer is a buffer written and read by different goroutines.
+type safeBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *safeBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *safeBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,157 @@
+// Command gobuzzctl manages fetchers of gobuzz server through its REST
+// API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gobuzz/pkg/client"
+)
+
+// command is a single gobuzzctl subcommand.
+type command struct {
+	usage   string
+	summary string
+	run     func(e *env, args []string) error
+}
+
+// commands of gobuzzctl by name.
+var commands = map[string]command{
+	"create":  {"create [-f file] [-name n] [-url u] [-interval s] [-tag t]... [-dedup] [-now]", "Create fetch, or find one of the same URL with -dedup.", runCreate},
+	"list":    {"list [-tag t] [-url u] [-limit n]", "List fetches.", runList},
+	"get":     {"get ID|NAME", "Show fetch.", runGet},
+	"update":  {"update ID|NAME [-f file] [-name n] [-url u] [-interval s] [-tag t]...", "Update fetch, given flags only without -f.", runUpdate},
+	"delete":  {"delete ID|NAME", "Delete fetch.", runDelete},
+	"pause":   {"pause ID|NAME [-until t]", "Pause fetch until resumed or until given time.", runPause},
+	"resume":  {"resume ID|NAME", "Resume paused fetch.", runResume},
+	"run":     {"run ID|NAME [-timeout d]", "Fetch at once and print the response.", runRun},
+	"test":    {"test [-f file] [-name n] [-url u] [-interval s] [-tag t]...", "Fetch definition once without storing it.", runTest},
+	"history": {"history ID|NAME [-limit n] [-outcome o] [-order asc|desc] [-from t] [-to t]", "Show response history.", runHistory},
+	"tail":    {"tail ID|NAME [-n count] [-every duration]", "Follow new responses until interrupted.", runTail},
+	"export":  {"export [-format json|ndjson] [-file path]", "Export fetch definitions.", runExport},
+	"import":  {"import [-atomic] file", "Import fetch definitions from JSON, NDJSON or YAML file, - for stdin.", runImport},
+}
+
+// env is shared by commands.
+type env struct {
+	ctx    context.Context
+	client *client.Client
+	stdin  io.Reader
+	stdout io.Writer
+	output string // default output format
+	usage  string // of running command
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gobuzzctl: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses global flags and runs the subcommand.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("gobuzzctl", flag.ContinueOnError)
+	configPath := fs.String("config", defaultConfigPath(), "config file")
+	server := fs.String("server", "", "server URL (default from config, GOBUZZ_SERVER or "+DefaultServer+")")
+	key := fs.String("key", "", "API key (default from config or GOBUZZ_API_KEY)")
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	explicit := false
+	fs.Visit(func(f *flag.Flag) { explicit = explicit || f.Name == "config" })
+	cfg, err := loadConfig(*configPath, explicit)
+	if err != nil {
+		return err
+	}
+	if *server != "" {
+		cfg.Server = *server
+	}
+	if *key != "" {
+		cfg.APIKey = *key
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fs.Usage()
+		if fs.Arg(0) == "" {
+			return flag.ErrHelp
+		}
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+
+	c := client.New(client.Config{Server: cfg.Server, APIKey: cfg.APIKey, HTTPClient: &http.Client{Timeout: 30 * time.Second}})
+	e := &env{ctx: ctx, client: c, stdin: stdin, stdout: stdout, output: cfg.Output, usage: cmd.usage}
+	return cmd.run(e, fs.Args()[1:])
+}
+
+// usage prints global flags and commands.
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintf(w, "Usage: gobuzzctl [flags] command [command flags] [-o table|json|yaml]\n\nFlags:\n")
+	fs.PrintDefaults()
+	fmt.Fprintf(w, "\nCommands:\n")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s\t%s\n", commands[name].usage, commands[name].summary)
+	}
+	tw.Flush()
+}
+
+// flags returns flag set of running command with -o output flag.
+func (e *env) flags(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stdout)
+	output := fs.String("o", e.output, "output format: table, json or yaml")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: gobuzzctl %s [-o table|json|yaml]\n", e.usage)
+		fs.PrintDefaults()
+	}
+	return fs, output
+}
+
+// parse parses command flags which may follow positional args and
+// checks their number.
+func parse(fs *flag.FlagSet, args []string, positional int) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(pos) != positional {
+		fs.Usage()
+		return nil, fmt.Errorf("%s expects %d argument(s), got %d", fs.Name(), positional, len(pos))
+	}
+	return pos, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gobuzz/pkg/client"
+	"github.com/gobuzz/pkg/domain/listing"
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// printer writes values in chosen format. Table rows are written by
+// row functions of commands.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// newPrinter returns printer of the format or error if it is unknown.
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return &printer{w: w, format: format}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q, table, json or yaml expected", format)
+}
+
+// print writes v as JSON or YAML, or as table with header and rows
+// returned by table.
+func (p *printer) print(v interface{}, header []string, table func() [][]string) error {
+	switch p.format {
+	case formatJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n", data)
+		return err
+	case formatYAML:
+		data, err := toYAML(v)
+		if err != nil {
+			return err
+		}
+		_, err = p.w.Write(data)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+	}
+	for _, row := range table() {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// toYAML encodes v as YAML with field names of its JSON encoding.
+func toYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(generic)
+}
+
+// fetchHeader and fetchRow define table of fetches.
+var fetchHeader = []string{"ID", "NAME", "URL", "INTERVAL", "TAGS", "STATE", "CREATED"}
+
+func fetchRow(f listing.Fetch) []string {
+	return []string{
+		f.ID,
+		orDash(f.Name),
+		f.URL,
+		fmt.Sprintf("%ds", f.Interval),
+		orDash(strings.Join(f.Tags, ",")),
+		fetchState(f),
+		f.CreatedAt.Local().Format(time.RFC3339),
+	}
+}
+
+// fetchState returns whether fetch is active or paused, with pause end
+// if it has one.
+func fetchState(f listing.Fetch) string {
+	switch {
+	case !f.Paused:
+		return "active"
+	case f.PausedUntil != nil:
+		return "paused until " + f.PausedUntil.Local().Format(time.RFC3339)
+	}
+	return "paused"
+}
+
+// historyHeader and historyRow define table of response history.
+var historyHeader = []string{"CREATED", "OUTCOME", "DURATION", "SIZE", "ERROR"}
+
+func historyRow(h client.Response) []string {
+	return []string{
+		h.Created().Local().Format(time.RFC3339),
+		h.Outcome,
+		fmt.Sprintf("%.3fs", h.Duration),
+		fmt.Sprint(len(h.Response)),
+		orDash(h.Error),
+	}
+}
+
+// tryHeader and tryRow define table of tried fetch.
+var tryHeader = []string{"STATUS", "OUTCOME", "DURATION", "SIZE", "ERROR"}
+
+func tryRow(r client.TryResult) []string {
+	size := fmt.Sprint(len(r.Response.Response))
+	if r.Truncated {
+		size += "+"
+	}
+	return []string{
+		fmt.Sprint(r.Status),
+		r.Outcome,
+		fmt.Sprintf("%.3fs", r.Duration),
+		size,
+		orDash(r.Error),
+	}
+}
+
+// orDash returns "-" for empty table cell.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -2,14 +2,28 @@ package main
 
 import (
 	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/reconciling"
+	"github.com/gobuzz/pkg/domain/replaying"
 	"github.com/gobuzz/pkg/domain/responding"
 	"github.com/gobuzz/pkg/http/rest"
+	"github.com/gobuzz/pkg/http/rpc"
+	"github.com/gobuzz/pkg/http/worker"
+	"github.com/gobuzz/pkg/ratelimit"
+	"github.com/gobuzz/pkg/storage/manifest"
 	"github.com/gobuzz/pkg/storage/memory"
+	"github.com/gobuzz/pkg/storage/memory/response"
 )
 
 func main() {
@@ -22,16 +36,157 @@ func main() {
 func run() error {
 	// Initializing storage and services.
 	s := new(memory.ResponseFetch)
-	adder := adding.NewService(&s.Fetches)        // adding service
-	respsr := responding.NewService(&s.Responses) // responsing service (for Gopher)
+	s.Responses.Codec = response.Gzip // response body compression
+	s.Tenants.Default = adding.Quota{MaxFetches: 100, MinInterval: 1}
+	s.Tenants.Global = adding.Limits{MaxActive: 1000, MaxRate: 50}
+	adder := adding.NewService(&s.Fetches, &s.Tenants)     // adding service
+	respsr := responding.NewService(&s.Responses)          // responsing service (for Gopher)
+	lister := listing.NewService(&s.Responses, &s.Fetches) // listing service
+	auth := authenticating.NewService(&s.Keys)             // API keys service
+
+	// Idempotency keys are kept for GOBUZZ_IDEMPOTENCY_WINDOW or a day.
+	var window time.Duration
+	if v := os.Getenv("GOBUZZ_IDEMPOTENCY_WINDOW"); v != "" {
+		var err error
+		if window, err = time.ParseDuration(v); err != nil || window <= 0 {
+			return fmt.Errorf("GOBUZZ_IDEMPOTENCY_WINDOW must be positive duration, got %q", v)
+		}
+	}
+	replayer := replaying.NewService(&s.Replays, window)
+
+	// Bootstrap admin key: taken from GOBUZZ_ADMIN_KEY or generated.
+	adminScopes := []string{authenticating.ScopeAdmin}
+	if secret := os.Getenv("GOBUZZ_ADMIN_KEY"); secret != "" {
+		if _, err := auth.RegisterKey("bootstrap", adding.DefaultTenant, secret, adminScopes); err != nil {
+			return fmt.Errorf("registering GOBUZZ_ADMIN_KEY: %w", err)
+		}
+	} else {
+		_, secret, err := auth.CreateKey("bootstrap", adding.DefaultTenant, adminScopes)
+		if err != nil {
+			return fmt.Errorf("creating admin key: %w", err)
+		}
+		fmt.Println("Generated admin API key:", secret)
+	}
+
+	// Global retention rules, fetch retention takes precedence.
+	retention := compacting.Policy{
+		MaxRecords: 10000,
+		MaxAge:     7 * 24 * time.Hour,
+		MaxBytes:   512 << 20, // 512MB
+	}
+	compactor := compacting.NewService(s, retention)
+	go compactor.Start(time.Minute, nil) // background compactor
+
+	// Gophers of all fetches, shared by API and manifest.
+	hosts := worker.NewHostLimiter(worker.HostLimits{
+		MaxConcurrent: 4,
+		PerSecond:     2,
+		Robots:        true,
+	})
+	workers := worker.NewPool(respsr, hosts, nil)
+	for tenant, fetches := range s.Fetches.All() { // stored ones as left
+		for _, f := range fetches {
+			workers.Restore(tenant, f)
+		}
+	}
+
+	// Fetches declared in GOBUZZ_MANIFEST are reconciled at startup,
+	// on SIGHUP and on file change.
+	var reconciler reconciling.Service
+	if path := os.Getenv("GOBUZZ_MANIFEST"); path != "" {
+		reconciler = reconciling.NewService(manifest.File{Path: path}, &s.Fetches, adder, workers)
+		if err := reconcile(reconciler); err != nil {
+			return fmt.Errorf("reconciling %s: %w", path, err)
+		}
+
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		changed := make(chan struct{}, 1)
+		go func() {
+			err := manifest.Watch(path, func() {
+				select {
+				case changed <- struct{}{}:
+				default: // reconcile is pending already
+				}
+			}, nil)
+			if err != nil {
+				log.Printf("Manifest changes are not watched: %v\n", err)
+			}
+		}()
+		go func() {
+			for {
+				select {
+				case <-hup:
+				case <-changed:
+				}
+				if err := reconcile(reconciler); err != nil {
+					log.Printf("Reconciling %s failed: %v\n", path, err)
+				}
+			}
+		}()
+	}
 
 	srv := &http.Server{
-		Addr:              "127.0.0.1:8080",
-		Handler:           rest.ServHandler(adder, respsr),
+		Addr: "127.0.0.1:8080",
+		Handler: rest.ServHandler(rest.Services{
+			Adder:      adder,
+			Responder:  respsr,
+			Lister:     lister,
+			Compactor:  compactor,
+			Auth:       auth,
+			Reconciler: reconciler,
+			Replayer:   replayer,
+			RateLimit: rest.RateLimit{
+				IP:  ratelimit.Rate{PerSecond: 20, Burst: 40},
+				Key: ratelimit.Rate{PerSecond: 10, Burst: 20},
+			},
+			Hosts:   hosts,
+			Workers: workers,
+			Deprecation: rest.Deprecation{ // unversioned /api aliases
+				Since:  time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+				Sunset: time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC),
+			},
+		}),
 		MaxHeaderBytes:    1 << 20, //1MB
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
-	fmt.Println("GoBuzz server is running: http://localhost:8080")
+	// gRPC API on its own port, GOBUZZ_GRPC_ADDR or 127.0.0.1:9090.
+	grpcAddr := os.Getenv("GOBUZZ_GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = "127.0.0.1:9090"
+	}
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		return fmt.Errorf("listening for gRPC: %w", err)
+	}
+	grpcSrv := rpc.NewServer(rpc.Services{
+		Adder:     adder,
+		Responder: respsr,
+		Lister:    lister,
+		Auth:      auth,
+		Hosts:     hosts,
+		Workers:   workers,
+	})
+	go func() {
+		if err := grpcSrv.Serve(lis); err != nil {
+			log.Printf("gRPC server stopped: %v\n", err)
+		}
+	}()
+
+	fmt.Println("GoBuzz server is running: http://localhost:8080, gRPC", grpcAddr)
 	return srv.ListenAndServe()
 }
+
+// reconcile applies manifest and logs applied changes.
+func reconcile(reconciler reconciling.Service) error {
+	plan, err := reconciler.Apply()
+	for _, c := range plan.Changes {
+		if c.Err != "" {
+			log.Printf("Manifest: %s %s/%s failed: %s\n", c.Action, c.Tenant, c.Name, c.Err)
+			continue
+		}
+		log.Printf("Manifest: %s %s/%s (id %s)\n", c.Action, c.Tenant, c.Name, c.ID)
+	}
+	return err
+}
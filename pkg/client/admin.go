@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/domain/reconciling"
+)
+
+// Admin calls below require API key with admin scope.
+
+// keySecret is API key sent back together with its secret.
+type keySecret struct {
+	authenticating.Key
+	Secret string `json:"key"`
+}
+
+// Keys returns all API keys without their secrets.
+func (c *Client) Keys(ctx context.Context) ([]authenticating.Key, error) {
+	var keys []authenticating.Key
+	_, err := c.do(ctx, request{Method: http.MethodGet, Path: "/admin/keys", Out: &keys})
+	return keys, err
+}
+
+// CreateKey creates API key of tenant with scopes and returns it with
+// its secret. Secret is never returned again.
+func (c *Client) CreateKey(ctx context.Context, name, tenant string, scopes []string) (authenticating.Key, string, error) {
+	body := struct {
+		Name   string   `json:"name"`
+		Tenant string   `json:"tenant"`
+		Scopes []string `json:"scopes"`
+	}{Name: name, Tenant: tenant, Scopes: scopes}
+	req, err := jsonRequest(http.MethodPost, "/admin/keys", body)
+	if err != nil {
+		return authenticating.Key{}, "", err
+	}
+	var key keySecret
+	req.Out = &key
+	_, err = c.do(ctx, req)
+	return key.Key, key.Secret, err
+}
+
+// RotateKey replaces secret of API key and returns the new one.
+func (c *Client) RotateKey(ctx context.Context, id string) (authenticating.Key, string, error) {
+	var key keySecret
+	_, err := c.do(ctx, request{Method: http.MethodPost, Path: "/admin/keys/" + url.PathEscape(id) + "/rotate", Out: &key})
+	return key.Key, key.Secret, err
+}
+
+// RevokeKey revokes API key.
+func (c *Client) RevokeKey(ctx context.Context, id string) error {
+	_, err := c.do(ctx, request{Method: http.MethodDelete, Path: "/admin/keys/" + url.PathEscape(id)})
+	return err
+}
+
+// Quota returns quota of tenant.
+func (c *Client) Quota(ctx context.Context, tenant string) (adding.Quota, error) {
+	var q adding.Quota
+	_, err := c.do(ctx, request{Method: http.MethodGet, Path: quotaPath(tenant), Out: &q})
+	return q, err
+}
+
+// SetQuota replaces quota of tenant and returns the stored one.
+func (c *Client) SetQuota(ctx context.Context, tenant string, q adding.Quota) (adding.Quota, error) {
+	req, err := jsonRequest(http.MethodPut, quotaPath(tenant), q)
+	if err != nil {
+		return adding.Quota{}, err
+	}
+	var stored adding.Quota
+	req.Out = &stored
+	_, err = c.do(ctx, req)
+	return stored, err
+}
+
+// CompactionReport returns totals of compaction runs.
+func (c *Client) CompactionReport(ctx context.Context) (compacting.Report, error) {
+	var r compacting.Report
+	_, err := c.do(ctx, request{Method: http.MethodGet, Path: "/admin/compaction", Out: &r})
+	return r, err
+}
+
+// Compact runs compaction of stored responses and returns its report.
+func (c *Client) Compact(ctx context.Context) (compacting.Report, error) {
+	var r compacting.Report
+	_, err := c.do(ctx, request{Method: http.MethodPost, Path: "/admin/compaction", Out: &r})
+	return r, err
+}
+
+// ManifestDiff returns changes which applying manifest would make.
+func (c *Client) ManifestDiff(ctx context.Context) (reconciling.Plan, error) {
+	var p reconciling.Plan
+	_, err := c.do(ctx, request{Method: http.MethodGet, Path: "/admin/manifest/diff", Out: &p})
+	return p, err
+}
+
+// quotaPath returns path of tenant quota.
+func quotaPath(tenant string) string {
+	return "/admin/tenants/" + url.PathEscape(tenant) + "/quota"
+}
@@ -0,0 +1,198 @@
+// Package client is a typed Go client of gobuzz REST API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults of Config.
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = 500 * time.Millisecond
+	DefaultMaxRetryWait = 30 * time.Second
+)
+
+// Config configures Client. Zero values take defaults, negative
+// MaxRetries disables retries.
+type Config struct {
+	Server       string       // base URL e.g. http://localhost:8080
+	APIKey       string       // sent in X-API-Key header
+	HTTPClient   *http.Client // http.DefaultClient by default
+	MaxRetries   int          // retries of a single call
+	RetryBackoff time.Duration
+	MaxRetryWait time.Duration // the longest wait between retries
+}
+
+// Client calls gobuzz REST API. It is safe for concurrent use.
+type Client struct {
+	cfg Config
+}
+
+// New creates client with given configuration.
+func New(cfg Config) *Client {
+	cfg.Server = strings.TrimSuffix(cfg.Server, "/")
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	if cfg.MaxRetryWait <= 0 {
+		cfg.MaxRetryWait = DefaultMaxRetryWait
+	}
+	return &Client{cfg: cfg}
+}
+
+// request is a single API call. Body is sent with ContentType, JSON by
+// default. Request with IdempotencyKey is retried like idempotent ones.
+// Response body is decoded into Out if it is set, otherwise it is copied
+// into Raw if that is set. With OutOnError error response which is not
+// an error envelope, like failed bulk import, is decoded into Out too.
+type request struct {
+	Method      string
+	Path        string // relative to /api/v1, may contain query
+	Query       url.Values
+	Body        []byte
+	ContentType string
+	Accept      string
+	Out         interface{}
+	Raw         io.Writer
+	OutOnError  bool
+
+	IdempotencyKey string
+}
+
+// jsonRequest returns request with v encoded as its body.
+func jsonRequest(method, path string, v interface{}) (request, error) {
+	body, err := json.Marshal(v)
+	return request{Method: method, Path: path, Body: body}, err
+}
+
+// do sends request and returns response headers. Error envelope is
+// returned as *Error. Calls rejected by rate limiting are retried, as
+// are idempotent calls which failed on network or server error.
+func (c *Client) do(ctx context.Context, req request) (http.Header, error) {
+	for attempt := 0; ; attempt++ {
+		h, retry, err := c.send(ctx, req)
+		if !retry || attempt >= c.cfg.MaxRetries {
+			return h, err
+		}
+
+		wait := c.cfg.RetryBackoff << attempt
+		var e *Error
+		if errors.As(err, &e) && e.RetryAfter > 0 {
+			wait = e.RetryAfter
+		}
+		if wait > c.cfg.MaxRetryWait {
+			wait = c.cfg.MaxRetryWait
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return h, err
+		case <-t.C:
+		}
+	}
+}
+
+// send makes a single attempt of request and reports whether it can be
+// retried.
+func (c *Client) send(ctx context.Context, req request) (http.Header, bool, error) {
+	path := req.Path
+	if !strings.HasPrefix(path, "/api/") {
+		path = "/api/v1" + path
+	}
+	if len(req.Query) > 0 {
+		path += "?" + req.Query.Encode()
+	}
+
+	var body io.Reader
+	if req.Body != nil {
+		body = bytes.NewReader(req.Body)
+	}
+	r, err := http.NewRequestWithContext(ctx, req.Method, c.cfg.Server+path, body)
+	if err != nil {
+		return nil, false, err
+	}
+	if c.cfg.APIKey != "" {
+		r.Header.Set("X-API-Key", c.cfg.APIKey)
+	}
+	if req.Body != nil {
+		contentType := req.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		r.Header.Set("Content-Type", contentType)
+	}
+	if req.Accept != "" {
+		r.Header.Set("Accept", req.Accept)
+	}
+	if req.IdempotencyKey != "" {
+		r.Header.Set("Idempotency-Key", req.IdempotencyKey)
+	}
+
+	idempotent := req.Method != http.MethodPost || req.IdempotencyKey != ""
+	res, err := c.cfg.HTTPClient.Do(r)
+	if err != nil {
+		return nil, idempotent && ctx.Err() == nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		e := &Error{StatusCode: res.StatusCode}
+		if sec, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			e.RetryAfter = time.Duration(sec) * time.Second
+		}
+		retry := res.StatusCode == http.StatusTooManyRequests || idempotent && res.StatusCode >= http.StatusInternalServerError
+
+		data, _ := io.ReadAll(res.Body)
+		if json.Unmarshal(data, e) == nil && e.Code != "" {
+			return res.Header, retry, e
+		}
+		if req.OutOnError && req.Out != nil && json.Unmarshal(data, req.Out) == nil {
+			return res.Header, false, nil
+		}
+		e.Code, e.Message = "http", strings.TrimSpace(res.Status+" "+string(data))
+		return res.Header, retry, e
+	}
+
+	switch {
+	case req.Out != nil && res.StatusCode != http.StatusNoContent:
+		err = json.NewDecoder(res.Body).Decode(req.Out)
+	case req.Raw != nil:
+		_, err = io.Copy(req.Raw, res.Body)
+	}
+	return res.Header, false, err
+}
+
+// nextCursor returns cursor of the next page from Link header, empty on
+// the last page.
+func nextCursor(h http.Header) string {
+	for _, link := range h.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			part = strings.TrimSpace(part)
+			if !strings.HasSuffix(part, `rel="next"`) {
+				continue
+			}
+			u, err := url.Parse(strings.Trim(strings.SplitN(part, ";", 2)[0], "<> "))
+			if err == nil {
+				return u.Query().Get("cursor")
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,438 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/gobuzz/pkg/client"
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/replaying"
+	"github.com/gobuzz/pkg/domain/responding"
+	"github.com/gobuzz/pkg/http/rest"
+	"github.com/gobuzz/pkg/http/worker"
+	"github.com/gobuzz/pkg/storage/memory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// upstream answers fetches instead of the network.
+type upstream func(r *http.Request) (*http.Response, error)
+
+func (u upstream) RoundTrip(r *http.Request) (*http.Response, error) {
+	return u(r)
+}
+
+var _ = Describe("The client", func() {
+	var (
+		server  *httptest.Server
+		respsr  responding.Service
+		secret  string
+		failing http.HandlerFunc // answers requests before the server if set
+		handler http.Handler
+		c       *Client
+		ctx     context.Context
+	)
+
+	BeforeEach(func() { // Configuration
+		failing = nil
+		ctx = context.Background()
+	})
+
+	JustBeforeEach(func() {
+		s := new(memory.ResponseFetch)
+		auth := authenticating.NewService(&s.Keys)
+		_, secret, _ = auth.CreateKey("ci", "", []string{authenticating.ScopeAdmin})
+		respsr = responding.NewService(&s.Responses)
+		fetched := upstream(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Age": {"7"}}, Body: ioutil.NopCloser(strings.NewReader("abc")), Request: r}, nil
+		})
+		handler = rest.ServHandler(rest.Services{
+			Adder:     adding.NewService(&s.Fetches, &s.Tenants),
+			Responder: respsr,
+			Workers:   worker.NewPool(respsr, nil, &http.Client{Transport: fetched}),
+			Lister:    listing.NewService(&s.Responses, &s.Fetches),
+			Compactor: compacting.NewService(s, compacting.Policy{}),
+			Auth:      auth,
+			Replayer:  replaying.NewService(&s.Replays, 0),
+		})
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing != nil {
+				failing(w, r)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		c = New(Config{Server: server.URL, APIKey: secret, RetryBackoff: time.Millisecond}) // Creation
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("When managing fetches", func() {
+		It("Should create, get, update and delete them.", func() {
+			id, err := c.CreateFetch(ctx, adding.Fetch{Name: "front", URL: "https://httpbin.org/range/15", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).NotTo(BeEmpty())
+
+			f, err := c.Fetch(ctx, "front")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.ID).To(Equal(id))
+
+			f, err = c.UpdateFetch(ctx, id, adding.Fetch{Name: "front", URL: "https://httpbin.org/range/15", Interval: 30})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Interval).To(Equal(30))
+
+			Expect(c.DeleteFetch(ctx, id)).To(Succeed())
+			_, err = c.Fetch(ctx, id)
+			Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+		})
+
+		It("Should find fetch of the same URL in dedup mode.", func() {
+			id, created, err := c.FindOrCreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 3600})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			same, created, err := c.FindOrCreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeFalse())
+			Expect(same).To(Equal(id))
+		})
+
+		It("Should pause and resume them.", func() {
+			id, err := c.CreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 3600})
+			Expect(err).NotTo(HaveOccurred())
+
+			until := time.Now().Add(time.Hour).Truncate(time.Second)
+			f, err := c.PauseFetch(ctx, id, until)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Paused).To(BeTrue())
+			Expect(f.PausedUntil).NotTo(BeNil())
+			Expect(f.PausedUntil.Equal(until)).To(BeTrue())
+
+			f, err = c.ResumeFetch(ctx, id)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Paused).To(BeFalse())
+
+			_, err = c.PauseFetch(ctx, "missing", time.Time{})
+			Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+		})
+
+		It("Should run them at once.", func() {
+			id, _, err := c.CreateFetchWith(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 3600}, CreateOptions{RunNow: true})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() int {
+				history, _, _ := c.History(ctx, id, HistoryOptions{})
+				return len(history)
+			}).Should(Equal(1))
+
+			r, err := c.RunFetch(ctx, id, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Response).To(Equal("abc"))
+			Expect(r.Manual).To(BeTrue())
+
+			_, err = c.RunFetch(ctx, "missing", 0)
+			Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+		})
+
+		It("Should try definition without storing it.", func() {
+			r, err := c.TryFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Status).To(Equal(http.StatusOK))
+			Expect(r.Headers.Get("Age")).To(Equal("7"))
+			Expect(r.Body()).To(Equal([]byte("abc")))
+			Expect(r.Outcome).To(Equal(listing.OutcomeOK))
+
+			fetches, _, err := c.Fetches(ctx, ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetches).To(BeEmpty())
+
+			_, err = c.TryFetch(ctx, adding.Fetch{URL: "woops", Interval: 60})
+			Expect(errors.Is(err, ErrValidation)).To(BeTrue())
+		})
+
+		It("Should list them across pages.", func() {
+			bulk, err := c.CreateFetches(ctx, []adding.Fetch{
+				{URL: "https://httpbin.org/range/15", Interval: 60, Tags: []string{"prod"}},
+				{URL: "https://httpbin.org/range/16", Interval: 60},
+				{URL: "https://httpbin.org/range/17", Interval: 60, Tags: []string{"prod"}},
+			}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bulk.Created).To(Equal(3))
+
+			page, next, err := c.Fetches(ctx, ListOptions{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page).To(HaveLen(2))
+			Expect(next).NotTo(BeEmpty())
+
+			page, next, err = c.Fetches(ctx, ListOptions{Limit: 2, Cursor: next})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page).To(HaveLen(1))
+			Expect(next).To(BeEmpty())
+
+			all, err := c.AllFetches(ctx, ListOptions{Tag: "prod", Limit: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(all).To(HaveLen(2))
+		})
+
+		It("Should export and import definitions.", func() {
+			_, err := c.CreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+
+			var buf bytes.Buffer
+			Expect(c.ExportFetches(ctx, &buf, MediaNDJSON)).To(Succeed())
+			bulk, err := c.ImportFetches(ctx, buf.Bytes(), MediaNDJSON, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bulk.Created).To(Equal(1))
+
+			definitions, err := c.Definitions(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(definitions).To(HaveLen(2))
+		})
+
+		It("Should report failed bulk definitions in result.", func() {
+			bulk, err := c.CreateFetches(ctx, []adding.Fetch{
+				{URL: "https://httpbin.org/range/15", Interval: 60},
+				{URL: "woops", Interval: 60},
+			}, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bulk.Failed).To(Equal(2))
+			Expect(errors.Is(bulk.Results[0].Error, ErrAborted)).To(BeTrue())
+			Expect(errors.Is(bulk.Results[1].Error, ErrValidation)).To(BeTrue())
+		})
+	})
+
+	Describe("When reading responses", func() {
+		var id string
+
+		JustBeforeEach(func() {
+			var err error
+			id, err = c.CreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 3600})
+			Expect(err).NotTo(HaveOccurred())
+			respsr.CreateRecord(responding.Response{StorageKeyID: id, Tenant: adding.DefaultTenant, Content: "abc", Duration: 0.1})
+			respsr.CreateRecord(responding.Response{StorageKeyID: id, Tenant: adding.DefaultTenant, Data: []byte{0, 1}, MediaType: "application/octet-stream", Duration: 0.2})
+		})
+
+		It("Should return history with decoded bodies.", func() {
+			history, next, err := c.History(ctx, id, HistoryOptions{Desc: true, Limit: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).NotTo(BeEmpty())
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Body()).To(Equal([]byte{0, 1}))
+			Expect(history[0].Created()).To(BeTemporally("~", time.Now(), time.Minute))
+
+			history, _, err = c.History(ctx, id, HistoryOptions{Desc: true, Cursor: next})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history[0].Body()).To(Equal([]byte("abc")))
+
+			_, _, err = c.History(ctx, id, HistoryOptions{Outcome: "woops"})
+			Expect(err).To(MatchError(`Outcome "woops" is not supported. (validation_failed, field outcome)`))
+		})
+
+		It("Should stream new responses until context is done.", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			bodies := make(chan string, 8)
+			done := make(chan error)
+			go func() {
+				done <- c.Follow(ctx, id, 1, 10*time.Millisecond, func(r Response) error {
+					bodies <- r.Response
+					return nil
+				})
+			}()
+
+			Eventually(bodies).Should(Receive(Equal("AAE=")))
+			time.Sleep(10 * time.Millisecond) // next response must be newer
+			respsr.CreateRecord(responding.Response{StorageKeyID: id, Tenant: adding.DefaultTenant, Content: "def", Duration: 0.3})
+			Eventually(bodies).Should(Receive(Equal("def")))
+			Consistently(bodies, "50ms").ShouldNot(Receive())
+
+			cancel()
+			Eventually(done).Should(Receive(MatchError(context.Canceled)))
+		})
+
+		Context("When fetch has not run yet.", func() {
+			var created string
+
+			JustBeforeEach(func() {
+				var err error
+				created, err = c.CreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/20", Interval: 3600})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("Should stream its first response.", func() {
+				history, _, err := c.History(ctx, created, HistoryOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(history).To(BeEmpty())
+
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				bodies := make(chan string, 8)
+				go c.Follow(ctx, created, 1, 10*time.Millisecond, func(r Response) error {
+					bodies <- r.Response
+					return nil
+				})
+
+				Consistently(bodies, "30ms").ShouldNot(Receive())
+				respsr.CreateRecord(responding.Response{StorageKeyID: created, Tenant: adding.DefaultTenant, Content: "first", Duration: 0.1})
+				Eventually(bodies).Should(Receive(Equal("first")))
+			})
+
+			It("Should keep polling server reporting its history as not found.", func() {
+				failing = func(w http.ResponseWriter, r *http.Request) {
+					if strings.HasSuffix(r.URL.Path, "/history") {
+						w.Header().Set("Content-Type", "application/json")
+						w.WriteHeader(http.StatusNotFound)
+						w.Write([]byte(`{"code":"not_found","message":"History of fetch not found."}`))
+						return
+					}
+					handler.ServeHTTP(w, r)
+				}
+
+				timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+				defer cancel()
+				err := c.Follow(timeout, created, 1, 10*time.Millisecond, func(r Response) error { return nil })
+				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+
+				err = c.Follow(ctx, "01HZX3V6Q8J5K2M9N4P7R1S3T6", 1, 10*time.Millisecond, func(r Response) error { return nil })
+				Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("When administering the server", func() {
+		It("Should manage API keys.", func() {
+			key, keySecret, err := c.CreateKey(ctx, "reader", "team-a", []string{authenticating.ScopeFetchersRead})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.Tenant).To(Equal("team-a"))
+
+			keys, err := c.Keys(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(2))
+
+			_, rotated, err := c.RotateKey(ctx, key.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rotated).NotTo(Equal(keySecret))
+
+			Expect(c.RevokeKey(ctx, key.ID)).To(Succeed())
+			reader := New(Config{Server: server.URL, APIKey: rotated})
+			_, _, err = reader.Fetches(ctx, ListOptions{})
+			Expect(errors.Is(err, ErrUnauthorized)).To(BeTrue())
+		})
+
+		It("Should enforce tenant quota.", func() {
+			q, err := c.SetQuota(ctx, adding.DefaultTenant, adding.Quota{MaxFetches: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Quota(ctx, adding.DefaultTenant)).To(Equal(q))
+
+			_, err = c.CreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = c.CreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/16", Interval: 60})
+			Expect(errors.Is(err, ErrQuotaExceeded)).To(BeTrue())
+		})
+
+		It("Should run compaction and report missing manifest.", func() {
+			_, err := c.Compact(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			report, err := c.CompactionReport(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Runs).To(Equal(1))
+
+			_, err = c.ManifestDiff(ctx)
+			Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+		})
+	})
+
+	Describe("When the server fails", func() {
+		var calls int32
+
+		BeforeEach(func() {
+			calls = 0
+		})
+
+		failFirst := func(n int32, status int) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) > n {
+					http.Error(w, `{"code":"retried","message":"Retried."}`, http.StatusTeapot)
+					return
+				}
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+			}
+		}
+
+		Context("When it is rate limiting.", func() {
+			BeforeEach(func() {
+				failing = failFirst(2, http.StatusTooManyRequests)
+			})
+
+			It("Should retry any call.", func() {
+				_, err := c.CreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 60})
+				Expect(err).To(MatchError("Retried. (retried)"))
+				Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+			})
+		})
+
+		Context("When it is unavailable.", func() {
+			BeforeEach(func() {
+				failing = failFirst(1, http.StatusServiceUnavailable)
+			})
+
+			It("Should retry idempotent calls only.", func() {
+				_, err := c.Fetch(ctx, "front")
+				Expect(err).To(MatchError("Retried. (retried)"))
+				Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+
+				atomic.StoreInt32(&calls, 0)
+				_, err = c.CreateFetches(ctx, []adding.Fetch{{URL: "https://httpbin.org/range/15", Interval: 60}}, false)
+				Expect(errors.Is(err, ErrServer)).To(BeTrue())
+				Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+			})
+		})
+
+		Context("When response of created fetch is lost.", func() {
+			BeforeEach(func() {
+				failing = func(w http.ResponseWriter, r *http.Request) {
+					if atomic.AddInt32(&calls, 1) == 1 {
+						handler.ServeHTTP(httptest.NewRecorder(), r)
+						w.WriteHeader(http.StatusBadGateway)
+						return
+					}
+					handler.ServeHTTP(w, r)
+				}
+			})
+
+			It("Should retry creation without duplicate.", func() {
+				id, err := c.CreateFetch(ctx, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 3600})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+
+				fetches, err := c.AllFetches(ctx, ListOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetches).To(HaveLen(1))
+				Expect(fetches[0].ID).To(Equal(id))
+			})
+		})
+
+		Context("When retries are exhausted.", func() {
+			BeforeEach(func() {
+				failing = failFirst(10, http.StatusTooManyRequests)
+			})
+
+			It("Should report the last error.", func() {
+				_, err := c.Fetch(ctx, "front")
+				Expect(errors.Is(err, ErrRateLimited)).To(BeTrue())
+				Expect(atomic.LoadInt32(&calls)).To(Equal(int32(DefaultMaxRetries + 1)))
+			})
+		})
+	})
+})
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Errors matched by *Error with errors.Is according to its code, or its
+// status code if the code is not known.
+var (
+	ErrValidation    = errors.New("validation failed")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
+	ErrNotFound      = errors.New("not found")
+	ErrConflict      = errors.New("conflict")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	ErrAborted       = errors.New("aborted")
+	ErrMismatch      = errors.New("idempotency mismatch")
+	ErrServer        = errors.New("server error")
+)
+
+// codeErrors maps codes of API error envelope into errors.
+var codeErrors = map[string]error{
+	"validation_failed":      ErrValidation,
+	"invalid_json":           ErrValidation,
+	"invalid_field":          ErrValidation,
+	"unknown_field":          ErrValidation,
+	"missing_field":          ErrValidation,
+	"empty_body":             ErrValidation,
+	"payload_too_large":      ErrValidation,
+	"unsupported_media_type": ErrValidation,
+	"unauthorized":           ErrUnauthorized,
+	"forbidden":              ErrForbidden,
+	"not_found":              ErrNotFound,
+	"conflict":               ErrConflict,
+	"rate_limited":           ErrRateLimited,
+	"quota_exceeded":         ErrQuotaExceeded,
+	"aborted":                ErrAborted,
+	"idempotency_mismatch":   ErrMismatch,
+	"internal":               ErrServer,
+}
+
+// statusErrors maps status codes into errors.
+var statusErrors = map[int]error{
+	http.StatusBadRequest:          ErrValidation,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrConflict,
+	http.StatusUnprocessableEntity: ErrMismatch,
+	http.StatusTooManyRequests:     ErrRateLimited,
+}
+
+// Error is error envelope sent back by the server. Responses which are
+// not an envelope have code "http".
+type Error struct {
+	StatusCode int           `json:"-"`
+	Code       string        `json:"code"`
+	Message    string        `json:"message"`
+	Field      string        `json:"field,omitempty"`
+	Details    interface{}   `json:"details,omitempty"`
+	RetryAfter time.Duration `json:"-"` // from Retry-After header
+}
+
+func (e *Error) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("%s (%s, field %s)", e.Message, e.Code, e.Field)
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+}
+
+// Is reports whether target is the Err* error matching e.
+func (e *Error) Is(target error) bool {
+	if err, ok := codeErrors[e.Code]; ok {
+		return err == target
+	}
+	if e.StatusCode >= http.StatusInternalServerError {
+		return target == ErrServer
+	}
+	return statusErrors[e.StatusCode] == target
+}
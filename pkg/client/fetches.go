@@ -0,0 +1,307 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
+// Media types of bulk payloads and exports.
+const (
+	MediaJSON   = "application/json"
+	MediaNDJSON = "application/x-ndjson"
+)
+
+// ListOptions filter and page fetches. Zero values are not sent.
+type ListOptions struct {
+	URL    string // substring of fetched URL
+	Tag    string
+	From   time.Time // creation time range
+	To     time.Time
+	Desc   bool // newest first
+	Limit  int
+	Cursor string // of the page returned by previous call
+}
+
+func (o ListOptions) query() url.Values {
+	q := pageQuery(o.From, o.To, o.Desc, o.Limit, o.Cursor)
+	setNonEmpty(q, "url", o.URL)
+	setNonEmpty(q, "tag", o.Tag)
+	return q
+}
+
+// BulkResult is the outcome of a single definition of bulk creation.
+// Index is the position of the definition in payload.
+type BulkResult struct {
+	Index int    `json:"index"`
+	ID    string `json:"id,omitempty"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// Bulk is the outcome of bulk creation.
+type Bulk struct {
+	Created int          `json:"created"`
+	Failed  int          `json:"failed"`
+	Results []BulkResult `json:"results"`
+}
+
+// CreateOptions modify fetch creation. Zero values are not sent.
+type CreateOptions struct {
+	Dedup  bool // return fetch of the same normalised URL if it exists
+	RunNow bool // run the first fetch at once instead of after interval
+}
+
+// CreateFetch creates fetch and returns its ID. Call is sent with random
+// idempotency key, so its retries do not create duplicates.
+func (c *Client) CreateFetch(ctx context.Context, f adding.Fetch) (string, error) {
+	id, _, err := c.CreateFetchWith(ctx, f, CreateOptions{})
+	return id, err
+}
+
+// FindOrCreateFetch creates fetch in dedup mode. ID of fetch of the same
+// normalised URL is returned if it exists, created reports otherwise.
+func (c *Client) FindOrCreateFetch(ctx context.Context, f adding.Fetch) (id string, created bool, err error) {
+	return c.CreateFetchWith(ctx, f, CreateOptions{Dedup: true})
+}
+
+// CreateFetchWith creates fetch with given options like CreateFetch.
+// Created reports whether fetch was not found in dedup mode.
+func (c *Client) CreateFetchWith(ctx context.Context, f adding.Fetch, opts CreateOptions) (id string, created bool, err error) {
+	req, err := jsonRequest(http.MethodPost, "/fetcher", f)
+	if err != nil {
+		return "", false, err
+	}
+	req.Query = url.Values{}
+	if opts.Dedup {
+		req.Query.Set("dedup", "true")
+	}
+	if opts.RunNow {
+		req.Query.Set("run_now", "true")
+	}
+	if req.IdempotencyKey, err = newIdempotencyKey(); err != nil {
+		return "", false, err
+	}
+	var res struct {
+		ID       string `json:"id"`
+		Existing bool   `json:"existing"`
+	}
+	req.Out = &res
+	_, err = c.do(ctx, req)
+	return res.ID, !res.Existing, err
+}
+
+// Fetch returns stored fetch. Fetches are referenced by ID or name.
+func (c *Client) Fetch(ctx context.Context, id string) (listing.Fetch, error) {
+	var f listing.Fetch
+	_, err := c.do(ctx, request{Method: http.MethodGet, Path: fetchPath(id), Out: &f})
+	return f, err
+}
+
+// UpdateFetch replaces definition of fetch and returns the stored one.
+func (c *Client) UpdateFetch(ctx context.Context, id string, f adding.Fetch) (listing.Fetch, error) {
+	var updated listing.Fetch
+	req, err := jsonRequest(http.MethodPut, fetchPath(id), f)
+	if err != nil {
+		return updated, err
+	}
+	req.Out = &updated
+	_, err = c.do(ctx, req)
+	return updated, err
+}
+
+// DeleteFetch deletes fetch together with its responses.
+func (c *Client) DeleteFetch(ctx context.Context, id string) error {
+	_, err := c.do(ctx, request{Method: http.MethodDelete, Path: fetchPath(id)})
+	return err
+}
+
+// PauseFetch stops worker of fetch until it is resumed, or until given
+// time if it is not zero, and returns the fetch.
+func (c *Client) PauseFetch(ctx context.Context, id string, until time.Time) (listing.Fetch, error) {
+	var f listing.Fetch
+	req := request{Method: http.MethodPost, Path: fetchPath(id) + "/pause", Out: &f}
+	if !until.IsZero() {
+		req.Query = url.Values{"until": {unixSeconds(until)}}
+	}
+	_, err := c.do(ctx, req)
+	return f, err
+}
+
+// ResumeFetch runs worker of paused fetch again and returns the fetch.
+func (c *Client) ResumeFetch(ctx context.Context, id string) (listing.Fetch, error) {
+	var f listing.Fetch
+	_, err := c.do(ctx, request{Method: http.MethodPost, Path: fetchPath(id) + "/resume", Out: &f})
+	return f, err
+}
+
+// RunFetch fetches URL of fetch at once and returns the response, which
+// is stored in history as manual one. Server waits for it up to timeout,
+// its default one if timeout is zero.
+func (c *Client) RunFetch(ctx context.Context, id string, timeout time.Duration) (Response, error) {
+	var r Response
+	req := request{Method: http.MethodPost, Path: fetchPath(id) + "/run", Out: &r}
+	if timeout > 0 {
+		req.Query = url.Values{"timeout": {timeout.String()}}
+	}
+	_, err := c.do(ctx, req)
+	return r, err
+}
+
+// TryResult is result of fetch definition tried without storing it.
+// Status and Headers are of upstream response, Status is 0 if URL was
+// not fetched. Body of Response is cut to 4096 bytes, Truncated reports
+// that.
+type TryResult struct {
+	Status    int         `json:"status"`
+	Headers   http.Header `json:"headers,omitempty"`
+	Truncated bool        `json:"truncated,omitempty"`
+	Response
+}
+
+// TryFetch validates fetch definition and fetches its URL once. Nothing
+// is stored and no worker is started.
+func (c *Client) TryFetch(ctx context.Context, f adding.Fetch) (TryResult, error) {
+	var r TryResult
+	req, err := jsonRequest(http.MethodPost, "/fetcher/test", f)
+	if err != nil {
+		return r, err
+	}
+	req.Out = &r
+	_, err = c.do(ctx, req)
+	return r, err
+}
+
+// Fetches returns a page of fetches and cursor of the next page, empty
+// on the last one.
+func (c *Client) Fetches(ctx context.Context, opts ListOptions) ([]listing.Fetch, string, error) {
+	var page []listing.Fetch
+	h, err := c.do(ctx, request{Method: http.MethodGet, Path: "/fetcher", Query: opts.query(), Out: &page})
+	if err != nil {
+		return nil, "", err
+	}
+	return page, nextCursor(h), nil
+}
+
+// AllFetches returns fetches of all pages from opts.Cursor on. Limit
+// is the page size, at most listing.MaxLimit.
+func (c *Client) AllFetches(ctx context.Context, opts ListOptions) ([]listing.Fetch, error) {
+	if opts.Limit == 0 {
+		opts.Limit = listing.MaxLimit
+	}
+	all := []listing.Fetch{}
+	for {
+		page, next, err := c.Fetches(ctx, opts)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, page...)
+		if next == "" {
+			return all, nil
+		}
+		opts.Cursor = next
+	}
+}
+
+// CreateFetches creates fetches in bulk. With atomic no fetch is
+// created unless all of them are valid. Failed definitions are
+// reported in Bulk, not by returned error.
+func (c *Client) CreateFetches(ctx context.Context, fetches []adding.Fetch, atomic bool) (Bulk, error) {
+	data, err := json.Marshal(fetches)
+	if err != nil {
+		return Bulk{}, err
+	}
+	return c.ImportFetches(ctx, data, MediaJSON, atomic)
+}
+
+// ImportFetches creates fetches from bulk payload of media type, JSON
+// array or NDJSON, as written by ExportFetches.
+func (c *Client) ImportFetches(ctx context.Context, payload []byte, media string, atomic bool) (Bulk, error) {
+	var res Bulk
+	_, err := c.do(ctx, request{
+		Method:      http.MethodPost,
+		Path:        "/fetcher/bulk",
+		Query:       url.Values{"atomic": {strconv.FormatBool(atomic)}},
+		Body:        payload,
+		ContentType: media,
+		Out:         &res,
+		OutOnError:  true,
+	})
+	return res, err
+}
+
+// ExportFetches writes definitions of all fetches into w as bulk payload
+// of media type, JSON array or NDJSON.
+func (c *Client) ExportFetches(ctx context.Context, w io.Writer, media string) error {
+	format := "json"
+	if media == MediaNDJSON {
+		format = "ndjson"
+	}
+	_, err := c.do(ctx, request{Method: http.MethodGet, Path: "/fetcher/export", Query: url.Values{"format": {format}}, Raw: w})
+	return err
+}
+
+// Definitions returns definitions of all fetches.
+func (c *Client) Definitions(ctx context.Context) ([]adding.Fetch, error) {
+	var buf bytes.Buffer
+	if err := c.ExportFetches(ctx, &buf, MediaJSON); err != nil {
+		return nil, err
+	}
+	var fetches []adding.Fetch
+	err := json.Unmarshal(buf.Bytes(), &fetches)
+	return fetches, err
+}
+
+// newIdempotencyKey returns random key identifying a single call.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// fetchPath returns path of a single fetch referenced by ID or name.
+func fetchPath(id string) string {
+	return "/fetcher/" + url.PathEscape(id)
+}
+
+// pageQuery returns query params of listing page.
+func pageQuery(from, to time.Time, desc bool, limit int, cursor string) url.Values {
+	q := url.Values{}
+	if !from.IsZero() {
+		q.Set("from", unixSeconds(from))
+	}
+	if !to.IsZero() {
+		q.Set("to", unixSeconds(to))
+	}
+	if desc {
+		q.Set("order", "desc")
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	setNonEmpty(q, "cursor", cursor)
+	return q
+}
+
+// setNonEmpty sets query param unless v is empty.
+func setNonEmpty(q url.Values, name, v string) {
+	if v != "" {
+		q.Set(name, v)
+	}
+}
+
+// unixSeconds formats t as Unix seconds accepted by time query params.
+func unixSeconds(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+)
+
+// Response is a single record of response history. Text body is in
+// Response as it is, binary one is base64 encoded.
+type Response struct {
+	Response   string                   `json:"response"`
+	Encoding   string                   `json:"encoding"`
+	MediaType  string                   `json:"media_type,omitempty"`
+	Duration   float64                  `json:"duration"`
+	Delay      float64                  `json:"limiter_delay"`
+	CreatedAt  float64                  `json:"created_at"`
+	Outcome    string                   `json:"outcome"`
+	Error      string                   `json:"error,omitempty"`
+	Manual     bool                     `json:"manual,omitempty"`
+	Assertions []responding.CheckResult `json:"assertions,omitempty"`
+}
+
+// Body returns decoded response body.
+func (r Response) Body() ([]byte, error) {
+	if r.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(r.Response)
+	}
+	return []byte(r.Response), nil
+}
+
+// Created returns creation time of response.
+func (r Response) Created() time.Time {
+	return time.Unix(0, int64(r.CreatedAt*float64(time.Second)))
+}
+
+// HistoryOptions filter, sort and page response history. Zero values
+// are not sent.
+type HistoryOptions struct {
+	Outcome string    // listing.Outcome* value
+	Sort    string    // listing.Sort* value
+	From    time.Time // creation time range
+	To      time.Time
+	Desc    bool
+	Limit   int
+	Cursor  string // of the page returned by previous call
+}
+
+func (o HistoryOptions) query() url.Values {
+	q := pageQuery(o.From, o.To, o.Desc, o.Limit, o.Cursor)
+	setNonEmpty(q, "outcome", o.Outcome)
+	setNonEmpty(q, "sort", o.Sort)
+	return q
+}
+
+// History returns a page of response history of fetch and cursor of the
+// next page, empty on the last one.
+func (c *Client) History(ctx context.Context, id string, opts HistoryOptions) ([]Response, string, error) {
+	var page []Response
+	h, err := c.do(ctx, request{Method: http.MethodGet, Path: fetchPath(id) + "/history", Query: opts.query(), Out: &page})
+	if err != nil {
+		return nil, "", err
+	}
+	return page, nextCursor(h), nil
+}
+
+// SeriesOptions select time range and aggregation of series. Zero
+// values are not sent.
+type SeriesOptions struct {
+	From time.Time
+	To   time.Time
+	Step time.Duration // points are aggregated into buckets of Step
+	Agg  string        // aggregation of bucket, avg by default
+}
+
+// Series returns values of fetch extractor name over time.
+func (c *Client) Series(ctx context.Context, id, name string, opts SeriesOptions) (listing.Series, error) {
+	q := pageQuery(opts.From, opts.To, false, 0, "")
+	if opts.Step > 0 {
+		q.Set("step", opts.Step.String())
+	}
+	setNonEmpty(q, "agg", opts.Agg)
+
+	var series listing.Series
+	path := fmt.Sprintf("%s/series/%s", fetchPath(id), url.PathEscape(name))
+	_, err := c.do(ctx, request{Method: http.MethodGet, Path: path, Query: q, Out: &series})
+	return series, err
+}
+
+// Follow streams responses of fetch into fn as they are stored, oldest
+// first, polling history every period. Last n responses stored before
+// the call are streamed first. Fetch which has not run yet is polled
+// until its first response. It returns when ctx is done, with error of
+// failed poll or error returned by fn.
+func (c *Client) Follow(ctx context.Context, id string, n int, every time.Duration, fn func(Response) error) error {
+	var last []Response
+	if n > 0 {
+		var err error
+		if last, _, err = c.History(ctx, id, HistoryOptions{Desc: true, Limit: n}); err != nil && !c.notRun(ctx, id, err) {
+			return err
+		}
+	}
+	for i, j := 0, len(last)-1; i < j; i, j = i+1, j-1 {
+		last[i], last[j] = last[j], last[i]
+	}
+
+	since := 0.0 // creation time of the newest response streamed
+	for {
+		for _, r := range last {
+			if r.CreatedAt <= since {
+				continue
+			}
+			if err := fn(r); err != nil {
+				return err
+			}
+			since = r.CreatedAt
+		}
+
+		t := time.NewTimer(every)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+
+		// Time range is inclusive, the newest streamed response comes
+		// back and is skipped above.
+		q := url.Values{"limit": {strconv.Itoa(listing.MaxLimit)}, "from": {strconv.FormatFloat(since, 'f', -1, 64)}}
+		last = nil
+		for {
+			var page []Response
+			h, err := c.do(ctx, request{Method: http.MethodGet, Path: fetchPath(id) + "/history", Query: q, Out: &page})
+			if err != nil {
+				if c.notRun(ctx, id, err) {
+					break
+				}
+				return err
+			}
+			last = append(last, page...)
+			next := nextCursor(h)
+			if next == "" {
+				break
+			}
+			q.Set("cursor", next)
+		}
+	}
+}
+
+// notRun reports whether history read of fetch with given ID failed
+// with err only because the fetch has not run yet. Servers before empty
+// history of such fetch report it as not found.
+func (c *Client) notRun(ctx context.Context, id string, err error) bool {
+	if !errors.Is(err, ErrNotFound) {
+		return false
+	}
+	_, err = c.Fetch(ctx, id)
+	return err == nil
+}
@@ -0,0 +1,65 @@
+package adding
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/jsonpath"
+)
+
+// Supported assertion types.
+const (
+	CheckRegex        = "regex"         // Value is a pattern matched against body
+	CheckContains     = "contains"      // Value must be present in body
+	CheckNotContains  = "not_contains"  // Value must be absent in body
+	CheckJSONPathEq   = "jsonpath_eq"   // value at Path must be equal to Value
+	CheckJSONPathLike = "jsonpath_like" // value at Path must match Value pattern
+	CheckMaxLatency   = "max_latency"   // fetch duration must not exceed Latency
+)
+
+// Check defines a single content assertion evaluated by Gopher against
+// fetched response content after each fetch.
+type Check struct {
+	Type    string  `json:"type"`
+	Path    string  `json:"path,omitempty"`
+	Value   string  `json:"value,omitempty"`
+	Latency float64 `json:"latency,omitempty"`
+}
+
+// validateChecks reports whether each of the assertions has known
+// type and all fields required by this type. Returns nil if so.
+func validateChecks(assertions []Check) error {
+	for i, a := range assertions {
+		var txt string
+		switch a.Type {
+		case CheckContains, CheckNotContains:
+			if a.Value == "" {
+				txt = fmt.Sprintf("Assertion %d: value must not be empty.", i)
+			}
+		case CheckRegex:
+			if _, err := regexp.Compile(a.Value); a.Value == "" || err != nil {
+				txt = fmt.Sprintf("Assertion %d: value must be a valid regular expression.", i)
+			}
+		case CheckJSONPathEq, CheckJSONPathLike:
+			if _, err := jsonpath.Parse(a.Path); err != nil {
+				txt = fmt.Sprintf("Assertion %d: path must be a valid JSONPath.", i)
+				break
+			}
+			if _, err := regexp.Compile(a.Value); a.Type == CheckJSONPathLike && err != nil {
+				txt = fmt.Sprintf("Assertion %d: value must be a valid regular expression.", i)
+			}
+		case CheckMaxLatency:
+			if a.Latency <= 0 {
+				txt = fmt.Sprintf("Assertion %d: latency must be greater than 0.", i)
+			}
+		default:
+			txt = fmt.Sprintf("Assertion %d: unknown type %q.", i, a.Type)
+		}
+
+		if txt != "" {
+			return &failure.Validation{Field: "assertions", Msg: txt}
+		}
+	}
+	return nil
+}
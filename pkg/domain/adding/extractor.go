@@ -0,0 +1,81 @@
+package adding
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/jsonpath"
+)
+
+// Supported extractor sources.
+const (
+	ExtractJSONPath = "jsonpath" // Expr is a JSONPath evaluated on body
+	ExtractRegex    = "regex"    // Expr is a pattern, Group selects capture group
+	ExtractHeader   = "header"   // Expr is a response header name
+)
+
+// Supported extracted value types.
+const (
+	ValueNumber = "number" // default
+	ValueString = "string"
+	ValueBool   = "bool"
+)
+
+// namePattern limits extractor names to characters safe in URL path.
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,64}$`)
+
+// Extractor defines a named value taken by Gopher from fetched
+// response and stored as a time series next to response history.
+type Extractor struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Expr   string `json:"expr"`
+	Group  int    `json:"group,omitempty"`
+	Type   string `json:"type,omitempty"`
+}
+
+// validateExtractors reports whether each of the extractors has unique
+// name, known source and type and valid expression. Returns nil if so.
+func validateExtractors(extractors []Extractor) error {
+	names := make(map[string]bool, len(extractors))
+	for i, e := range extractors {
+		var txt string
+		switch {
+		case !namePattern.MatchString(e.Name):
+			txt = fmt.Sprintf("Extractor %d: name must match %s.", i, namePattern)
+		case names[e.Name]:
+			txt = fmt.Sprintf("Extractor %d: name %q is already used.", i, e.Name)
+		case e.Type != "" && e.Type != ValueNumber && e.Type != ValueString && e.Type != ValueBool:
+			txt = fmt.Sprintf("Extractor %d: unknown value type %q.", i, e.Type)
+		}
+
+		if txt == "" {
+			switch e.Source {
+			case ExtractJSONPath:
+				if _, err := jsonpath.Parse(e.Expr); err != nil {
+					txt = fmt.Sprintf("Extractor %d: expr must be a valid JSONPath.", i)
+				}
+			case ExtractRegex:
+				re, err := regexp.Compile(e.Expr)
+				if e.Expr == "" || err != nil {
+					txt = fmt.Sprintf("Extractor %d: expr must be a valid regular expression.", i)
+				} else if e.Group < 0 || e.Group > re.NumSubexp() {
+					txt = fmt.Sprintf("Extractor %d: group must be in range [0, %d].", i, re.NumSubexp())
+				}
+			case ExtractHeader:
+				if e.Expr == "" {
+					txt = fmt.Sprintf("Extractor %d: expr must be a header name.", i)
+				}
+			default:
+				txt = fmt.Sprintf("Extractor %d: unknown source %q.", i, e.Source)
+			}
+		}
+
+		if txt != "" {
+			return &failure.Validation{Field: "extractors", Msg: txt}
+		}
+		names[e.Name] = true
+	}
+	return nil
+}
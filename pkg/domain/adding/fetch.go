@@ -1,7 +1,15 @@
 package adding
 
-// Fetch defines incoming fetch request JSON data
+// Fetch defines incoming fetch request JSON data. Tenant is taken
+// from API key used for creating the fetch. Name is optional and
+// unique within tenant.
 type Fetch struct {
-	URL      string `json:"url"`
-	Interval int    `json:"interval"`
+	Tenant     string      `json:"-"`
+	Name       string      `json:"name,omitempty"`
+	URL        string      `json:"url"`
+	Interval   int         `json:"interval"`
+	Assertions []Check     `json:"assertions,omitempty"`
+	Extractors []Extractor `json:"extractors,omitempty"`
+	Retention  *Retention  `json:"retention,omitempty"`
+	Tags       []string    `json:"tags,omitempty"`
 }
@@ -0,0 +1,34 @@
+package adding
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/failure"
+)
+
+// RetryAfter is the delay suggested to clients rejected by global
+// limits. Capacity is freed when fetches are paused or deleted.
+const RetryAfter = time.Minute
+
+// Limits defines server wide limits shared by all tenants. Zero value
+// of a field means no limit.
+type Limits struct {
+	MaxActive int     `json:"max_active"` // active fetchers
+	MaxRate   float64 `json:"max_rate"`   // aggregate outbound fetches per second
+}
+
+// validateLimits reports whether fetch with given interval can be
+// added next to active fetchers doing rate fetches per second in total.
+// Returns nil if so.
+func validateLimits(l Limits, active int, rate float64, interval int) error {
+	switch {
+	case l.MaxActive > 0 && active >= l.MaxActive:
+		txt := fmt.Sprintf("Server limit of %d active fetches has been reached.", l.MaxActive)
+		return &failure.Busy{Msg: txt, RetryAfter: RetryAfter}
+	case l.MaxRate > 0 && rate+1/float64(interval) > l.MaxRate:
+		txt := fmt.Sprintf("Server limit of %g fetches per second has been reached.", l.MaxRate)
+		return &failure.Busy{Msg: txt, RetryAfter: RetryAfter}
+	}
+	return nil
+}
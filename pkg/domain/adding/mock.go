@@ -1,15 +1,97 @@
 package adding
 
-import (
-	"fmt"
-	"net/http"
-)
+import "time"
 
 //FakeRepositoryAdder defines FetchCreate mock.
-type FakeRepositoryAdder struct{}
+type FakeRepositoryAdder struct {
+	Count int               // number of fetches reported for any tenant
+	Rate  float64           // aggregate fetches per second reported by Load
+	Names map[string]string // IDs of named fetches of any tenant
+	URLs  map[string]string // IDs of fetches of any tenant by NormalURL
+
+	Intervals map[string]int  // intervals of fetches of any tenant by ID
+	Paused    map[string]bool // paused fetches of any tenant by ID
+}
+
+// FakeID is the ID of each fetch created by FakeRepositoryAdder.
+const FakeID = "01HZX3V6Q8J5K2M9N4P7R1S3T5"
 
 //CreateRecord implements RepositoryAdder interface.
-func (f *FakeRepositoryAdder) CreateRecord(record Fetch) ServiceValidation {
-	txt := fmt.Sprintf("Record has been insert into fetch db.\n")
-	return ServiceValidation{StorageKeyID: 0, Status: http.StatusOK, Msg: txt}
+func (f *FakeRepositoryAdder) CreateRecord(record Fetch) (string, error) {
+	return FakeID, nil
+}
+
+// UpdateRecord implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) UpdateRecord(tenant, id string, record Fetch) error {
+	return nil
+}
+
+// DeleteRecord implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) DeleteRecord(tenant, id string) bool {
+	return true
+}
+
+// FindRecord implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) FindRecord(tenant, name string) (string, bool) {
+	id, ok := f.Names[name]
+	return id, ok
+}
+
+// MatchRecord implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) MatchRecord(tenant, url string) (string, bool) {
+	id, ok := f.URLs[url]
+	return id, ok
+}
+
+// PauseRecord implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) PauseRecord(tenant, id string, until time.Time) bool {
+	return id == FakeID
+}
+
+// ResumeRecord implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) ResumeRecord(tenant, id string) bool {
+	return id == FakeID
+}
+
+// RecordInterval implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) RecordInterval(tenant, id string) (int, bool) {
+	interval, ok := f.Intervals[id]
+	return interval, ok
+}
+
+// RecordPaused implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) RecordPaused(tenant, id string) (bool, bool) {
+	return f.Paused[id], id == FakeID
+}
+
+// CountRecords implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) CountRecords(tenant string) int {
+	return f.Count
+}
+
+// Load implements RepositoryAdder interface.
+func (f *FakeRepositoryAdder) Load() (int, float64) {
+	return f.Count, f.Rate
+}
+
+// FakeRepositoryQuotas defines RepositoryQuotas mock returning
+// the same quota for each tenant.
+type FakeRepositoryQuotas struct {
+	Default Quota
+	Global  Limits
+}
+
+// Quota implements RepositoryQuotas interface.
+func (f *FakeRepositoryQuotas) Quota(tenant string) Quota {
+	return f.Default
+}
+
+// SetQuota implements RepositoryQuotas interface.
+func (f *FakeRepositoryQuotas) SetQuota(tenant string, quota Quota) {
+	f.Default = quota
+}
+
+// Limits implements RepositoryQuotas interface.
+func (f *FakeRepositoryQuotas) Limits() Limits {
+	return f.Global
 }
@@ -0,0 +1,27 @@
+package adding
+
+import (
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/ulid"
+)
+
+// validateName reports whether optional fetch name is well formed.
+// Names share extractor names pattern, safe in URL path. Fetch is
+// addressed by ID or name, so name must not look like ID nor be one
+// of static fetcher routes.
+func validateName(name string) error {
+	switch {
+	case name == "":
+	case !namePattern.MatchString(name):
+		return failure.Invalid("name", "Name must match %s.", namePattern)
+	case ulid.Valid(name):
+		return failure.Invalid("name", "Name must not have form of fetch ID.")
+	case reservedNames[name]:
+		return failure.Invalid("name", "Name %q is reserved.", name)
+	}
+	return nil
+}
+
+// reservedNames are static path segments of fetcher routes, fetch
+// with such name could not be addressed by it.
+var reservedNames = map[string]bool{"export": true, "bulk": true, "test": true}
@@ -0,0 +1,45 @@
+package adding
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gobuzz/pkg/domain/failure"
+)
+
+// DefaultTenant owns fetches created with API keys without tenant.
+const DefaultTenant = "default"
+
+// tenantPattern limits tenant names to characters safe in URL path.
+var tenantPattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// ValidTenant reports whether tenant name is well formed.
+func ValidTenant(tenant string) bool {
+	return tenantPattern.MatchString(tenant)
+}
+
+// Quota defines per tenant limits. Zero value of a field means no limit.
+type Quota struct {
+	MaxFetches  int `json:"max_fetches"`
+	MinInterval int `json:"min_interval"` // seconds
+}
+
+// RepositoryQuotas provides tenant quotas and global limits repository.
+type RepositoryQuotas interface {
+	Quota(tenant string) Quota
+	SetQuota(tenant string, quota Quota)
+	Limits() Limits
+}
+
+// validateQuota reports whether tenant with count fetches can add
+// fetch with given interval. Returns nil if so.
+func validateQuota(q Quota, count, interval int) error {
+	switch {
+	case q.MaxFetches > 0 && count >= q.MaxFetches:
+		txt := fmt.Sprintf("Tenant fetches quota of %d has been exceeded.", q.MaxFetches)
+		return &failure.Quota{Msg: txt}
+	case interval < q.MinInterval:
+		return failure.Invalid("interval", "Interval value must be greater or equal %d.", q.MinInterval)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package adding
+
+import "github.com/gobuzz/pkg/domain/failure"
+
+// Retention defines per fetch rules for keeping response history.
+// Zero value of a field means that global rule is used.
+type Retention struct {
+	MaxRecords int `json:"max_records,omitempty"`
+	MaxAge     int `json:"max_age,omitempty"` // seconds
+	MaxBytes   int `json:"max_bytes,omitempty"`
+}
+
+// validateRetention reports whether retention rules are not negative.
+// Returns nil if so or if retention is not set.
+func validateRetention(r *Retention) error {
+	if r == nil {
+		return nil
+	}
+
+	switch {
+	case r.MaxRecords < 0:
+		return failure.Invalid("retention", "Retention max_records must be greater or equal 0.")
+	case r.MaxAge < 0:
+		return failure.Invalid("retention", "Retention max_age must be greater or equal 0.")
+	case r.MaxBytes < 0:
+		return failure.Invalid("retention", "Retention max_bytes must be greater or equal 0.")
+	}
+	return nil
+}
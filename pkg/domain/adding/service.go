@@ -2,52 +2,331 @@ package adding
 
 import (
 	"fmt"
-	"net/http"
 	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/failure"
 )
 
 // RepositoryAdder provides adding functionality into fetch repository.
 type RepositoryAdder interface {
-	CreateRecord(fetch Fetch) ServiceValidation
+	CreateRecord(fetch Fetch) (string, error)            // fails with failure.Conflict if name is used
+	UpdateRecord(tenant, id string, fetch Fetch) error   // fails with failure.NotFound or failure.Conflict
+	DeleteRecord(tenant, id string) bool                 // false if there is no such fetch
+	FindRecord(tenant, name string) (string, bool)       // ID of tenant fetch with given name
+	MatchRecord(tenant, url string) (string, bool)       // ID of the oldest tenant fetch with given NormalURL
+	PauseRecord(tenant, id string, until time.Time) bool // zero until pauses until resumed
+	ResumeRecord(tenant, id string) bool
+	RecordInterval(tenant, id string) (int, bool) // false if there is no such fetch
+	RecordPaused(tenant, id string) (bool, bool)  // paused until resumed or pause end
+	CountRecords(tenant string) int
+	Load() (active int, rate float64) // active fetches of all tenants and their fetches per second
 }
 
 // Service defines RepositoryAdder operation.
 type Service struct {
 	fetchRep RepositoryAdder
+	quotaRep RepositoryQuotas
+	mu       *sync.Mutex // serializes quota and limits check and record creation
+}
+
+// Result is the outcome of creating a single fetch of a batch. ID is
+// empty if Err is set.
+type Result struct {
+	ID  string
+	Err error
+}
+
+// CreateRecord provides adding fetch into Service repository. Returns
+// ID of the fetch, globally unique.
+func (s *Service) CreateRecord(record Fetch) (string, error) {
+	if err := validate(&record); err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.create(record)
+}
+
+// FindOrCreateRecord is CreateRecord in dedup mode. If tenant fetch
+// requesting the same normalised URL exists, its ID is returned instead
+// and created is false. Fetches are GET requests without headers or
+// body, so URL is the whole request. The existing fetch keeps its own
+// definition.
+func (s *Service) FindOrCreateRecord(record Fetch) (id string, created bool, err error) {
+	if err := validate(&record); err != nil {
+		return "", false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.fetchRep.MatchRecord(record.Tenant, NormalURL(record.URL)); ok {
+		return id, false, nil
+	}
+	id, err = s.create(record)
+	return id, err == nil, err
+}
+
+// create checks name and capacity of valid record and adds it into
+// repository. Must be called with s.mu held.
+func (s *Service) create(record Fetch) (string, error) {
+	if err := s.checkName(record, ""); err != nil {
+		return "", err
+	}
+
+	active, rate := s.fetchRep.Load()
+	if err := s.checkCapacity(record, s.fetchRep.CountRecords(record.Tenant), active, rate); err != nil {
+		return "", err
+	}
+
+	return s.fetchRep.CreateRecord(record)
+}
+
+// UpdateRecord replaces definition of tenant fetch with given ID. Tenant
+// quota of fetches and global limit of active fetches are not checked
+// again, interval must still respect tenant minimum one and global rate
+// limit with the old interval of the fetch replaced.
+func (s *Service) UpdateRecord(tenant, id string, record Fetch) error {
+	record.Tenant = tenant
+	if err := validate(&record); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkName(record, id); err != nil {
+		return err
+	}
+
+	if min := s.quotaRep.Quota(record.Tenant).MinInterval; record.Interval < min {
+		return failure.Invalid("interval", "Interval value must be greater or equal %d.", min)
+	}
+
+	// Only faster fetch can exceed the rate, slowing down is always allowed.
+	if old, ok := s.fetchRep.RecordInterval(tenant, id); ok && record.Interval < old {
+		_, rate := s.fetchRep.Load()
+		if err := validateLimits(s.quotaRep.Limits(), 0, rate-1/float64(old), record.Interval); err != nil {
+			return err
+		}
+	}
+
+	return s.fetchRep.UpdateRecord(tenant, id, record)
+}
+
+// DeleteRecord removes tenant fetch with given ID.
+func (s *Service) DeleteRecord(tenant, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.fetchRep.DeleteRecord(tenant, id) {
+		return failure.Missing("Fetch %q not found.", id)
+	}
+	return nil
+}
+
+// PauseRecord pauses tenant fetch with given ID until it is resumed, or
+// until given time if it is not zero. Definition and history of paused
+// fetch are kept.
+func (s *Service) PauseRecord(tenant, id string, until time.Time) error {
+	if !until.IsZero() && !until.After(time.Now()) {
+		return failure.Invalid("until", "Pause end must be in the future.")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.fetchRep.PauseRecord(tenant, id, until) {
+		return failure.Missing("Fetch %q not found.", id)
+	}
+	return nil
 }
 
-// ServiceValidation represetns response body sending to client
-// when validation check fails.
-type ServiceValidation struct {
-	StorageKeyID int
-	Status       int
-	Msg          string
+// ResumeRecord resumes paused tenant fetch with given ID. Resuming fetch
+// which is not paused fails with failure.Conflict.
+func (s *Service) ResumeRecord(tenant, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paused, ok := s.fetchRep.RecordPaused(tenant, id)
+	if !ok {
+		return failure.Missing("Fetch %q not found.", id)
+	}
+	if !paused {
+		return &failure.Conflict{Msg: fmt.Sprintf("Fetch %q is not paused.", id)}
+	}
+	s.fetchRep.ResumeRecord(tenant, id)
+	return nil
+}
+
+// Validate reports whether record definition is valid without creating
+// it. Empty tenant is the default one.
+func (s *Service) Validate(record Fetch) error {
+	return validate(&record)
+}
+
+// CreateRecords adds batch of fetches into Service repository and
+// returns result of each of them in the same order. Quota and limits
+// count fetches of the batch created before. If atomic is set and any
+// fetch is not valid, none of them is created and the valid ones are
+// reported as aborted.
+func (s *Service) CreateRecords(records []Fetch, atomic bool) []Result {
+	results := make([]Result, len(records))
+	for i := range records {
+		if err := validate(&records[i]); err != nil {
+			results[i] = Result{Err: err}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	failed := false
+	counts := make(map[string]int)
+	names := make(map[string]bool) // tenant/name of the batch fetches
+	active, rate := s.fetchRep.Load()
+	for i, record := range records {
+		if results[i].Err != nil {
+			failed = true
+			continue
+		}
+
+		named := record.Tenant + "/" + record.Name
+		err := s.checkName(record, "")
+		if err == nil && record.Name != "" && names[named] {
+			err = &failure.Conflict{Msg: fmt.Sprintf("Fetch name %q is already used.", record.Name)}
+		}
+		if err != nil {
+			results[i] = Result{Err: err}
+			failed = true
+			continue
+		}
+		if record.Name != "" {
+			names[named] = true
+		}
+
+		count, ok := counts[record.Tenant]
+		if !ok {
+			count = s.fetchRep.CountRecords(record.Tenant)
+		}
+		if err := s.checkCapacity(record, count, active, rate); err != nil {
+			results[i] = Result{Err: err}
+			failed = true
+			continue
+		}
+		counts[record.Tenant] = count + 1
+		active++
+		rate += 1 / float64(record.Interval)
+	}
+
+	if atomic && failed {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i] = Result{Err: &failure.Aborted{Msg: "Fetch not created, other fetch of the batch failed."}}
+			}
+		}
+		return results
+	}
+
+	for i, record := range records {
+		if results[i].Err != nil {
+			continue
+		}
+		id, err := s.fetchRep.CreateRecord(record)
+		results[i] = Result{ID: id, Err: err}
+	}
+	return results
+}
+
+// checkName reports whether record name is not used by other tenant
+// fetch than the one with given ID. Repository enforces it too, early
+// check fails whole atomic batch. Must be called with s.mu held.
+func (s *Service) checkName(record Fetch, id string) error {
+	if record.Name == "" {
+		return nil
+	}
+	if found, ok := s.fetchRep.FindRecord(record.Tenant, record.Name); ok && found != id {
+		return &failure.Conflict{Msg: fmt.Sprintf("Fetch name %q is already used.", record.Name)}
+	}
+	return nil
 }
 
-// CreateRecord provides adding fetch into Service repository.
-func (s *Service) CreateRecord(record Fetch) ServiceValidation {
+// checkCapacity reports whether record fits into quota of its tenant
+// having count fetches and into global limits with active fetches
+// doing rate fetches per second. Must be called with s.mu held.
+func (s *Service) checkCapacity(record Fetch, count, active int, rate float64) error {
+	if err := validateQuota(s.quotaRep.Quota(record.Tenant), count, record.Interval); err != nil {
+		return err
+	}
+	return validateLimits(s.quotaRep.Limits(), active, rate, record.Interval)
+}
+
+// validate sets default tenant of record and reports whether its
+// definition is valid.
+func validate(record *Fetch) error {
 
 	// Validation logic...
 	// pattern matching: http|https://httpbin.org/range|delay/upTo6Digits, 1st other than 0
-	pattern := `^https://httpbin.org/(range|delay)/([1-9]|[1-9]{1,5})$`
+	pattern := `^https?://httpbin.org/(range|delay)/[1-9][0-9]{0,5}$`
 	invalidPath, _ := regexp.MatchString(pattern, record.URL)
 
+	if record.Tenant == "" {
+		record.Tenant = DefaultTenant
+	}
+
 	switch {
+	case !ValidTenant(record.Tenant):
+		return failure.Invalid("tenant", "Tenant name is not valid.")
 	case record.Interval <= 0 && !invalidPath:
-		txt := fmt.Sprintf("Interval and URL path are not accepted.\n")
-		return ServiceValidation{StorageKeyID: -1, Status: http.StatusBadRequest, Msg: txt}
+		return failure.Invalid("url", "Interval and URL path are not accepted.")
 	case !invalidPath:
-		txt := fmt.Sprintf("URL path is not accepted.\n")
-		return ServiceValidation{StorageKeyID: -1, Status: http.StatusBadRequest, Msg: txt}
+		return failure.Invalid("url", "URL path is not accepted.")
 	case record.Interval <= 0:
-		txt := fmt.Sprintf("Interval value must be greater than 0.\n")
-		return ServiceValidation{StorageKeyID: -1, Status: http.StatusBadRequest, Msg: txt}
+		return failure.Invalid("interval", "Interval value must be greater than 0.")
 	}
 
-	return s.fetchRep.CreateRecord(record)
+	if err := validateName(record.Name); err != nil {
+		return err
+	}
+
+	if err := validateChecks(record.Assertions); err != nil {
+		return err
+	}
+
+	if err := validateExtractors(record.Extractors); err != nil {
+		return err
+	}
+
+	if err := validateRetention(record.Retention); err != nil {
+		return err
+	}
+
+	return validateTags(record.Tags)
+}
+
+// Quota returns quota of the tenant.
+func (s *Service) Quota(tenant string) Quota {
+	return s.quotaRep.Quota(tenant)
+}
+
+// SetQuota replaces quota of the tenant.
+func (s *Service) SetQuota(tenant string, quota Quota) error {
+	switch {
+	case !ValidTenant(tenant):
+		return failure.Invalid("tenant", "Tenant name is not valid.")
+	case quota.MaxFetches < 0 || quota.MinInterval < 0:
+		return failure.Invalid("quota", "Quota values must be greater or equal 0.")
+	}
+
+	s.quotaRep.SetQuota(tenant, quota)
+	return nil
 }
 
 // NewService creates an adding service with the necessary dependencies.
-func NewService(r RepositoryAdder) Service {
-	return Service{r}
+func NewService(r RepositoryAdder, q RepositoryQuotas) Service {
+	return Service{fetchRep: r, quotaRep: q, mu: new(sync.Mutex)}
 }
@@ -1,10 +1,10 @@
 package adding_test
 
 import (
-	"fmt"
-	"net/http"
+	"time"
 
 	. "github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -12,7 +12,18 @@ import (
 // testContent is an internal aggregate for creating tableTest slice.
 type testContent struct {
 	Fetch
-	ServiceValidation
+	ID  string
+	Err error
+}
+
+// expectResult checks CreateRecord results against expected ones.
+func expectResult(id string, err error, el testContent) {
+	Expect(id).To(Equal(el.ID))
+	if el.Err == nil {
+		Expect(err).NotTo(HaveOccurred())
+		return
+	}
+	Expect(err).To(Equal(el.Err))
 }
 
 var _ = Describe("The adding service", func() {
@@ -22,40 +33,39 @@ var _ = Describe("The adding service", func() {
 			data     []testContent
 			adder    Service
 			fetchRep FakeRepositoryAdder
+			quotaRep FakeRepositoryQuotas
 		)
 
 		BeforeEach(func() { // Configuration
 			data = []testContent{
 				{
-					Fetch{"http://httpbin.org/range/15", 10},
-					ServiceValidation{0, http.StatusOK, fmt.Sprintf("Record has been insert into fetch db.\n")},
+					Fetch{URL: "http://httpbin.org/range/15", Interval: 10},
+					FakeID, nil,
 				},
 				{
-					Fetch{"http://httpbin.org/range/20", 14},
-					ServiceValidation{0, http.StatusOK, fmt.Sprintf("Record has been insert into fetch db.\n")},
+					Fetch{URL: "http://httpbin.org/range/20", Interval: 14},
+					FakeID, nil,
 				},
 				{
-					Fetch{"http://httpbin.org/delay/150", 15},
-					ServiceValidation{0, http.StatusOK, fmt.Sprintf("Record has been insert into fetch db.\n")},
+					Fetch{URL: "http://httpbin.org/delay/150", Interval: 15},
+					FakeID, nil,
 				},
 				{
-					Fetch{"https://httpbin.org/delay/3000", 16},
-					ServiceValidation{0, http.StatusOK, fmt.Sprintf("Record has been insert into fetch db.\n")},
+					Fetch{URL: "https://httpbin.org/delay/3000", Interval: 16},
+					FakeID, nil,
 				},
 			}
 		})
 
 		JustBeforeEach(func() {
-			adder = NewService(&fetchRep) // Creation
+			adder = NewService(&fetchRep, &quotaRep) // Creation
 		})
 
 		Context("When fetch data is valid.", func() {
-			It("Should return ID: 0, http.StatusOK, and record add db msg.", func() {
+			It("Should return fetch ID and no error.", func() {
 				for _, element := range data {
-					serviceVal := adder.CreateRecord(element.Fetch)
-					Expect(serviceVal.StorageKeyID).To(Equal(element.ServiceValidation.StorageKeyID))
-					Expect(serviceVal.Status).To(Equal(element.ServiceValidation.Status))
-					Expect(serviceVal.Msg).To(Equal(element.ServiceValidation.Msg))
+					id, err := adder.CreateRecord(element.Fetch)
+					expectResult(id, err, element)
 				}
 			})
 
@@ -63,49 +73,424 @@ var _ = Describe("The adding service", func() {
 				BeforeEach(func() { // Configuration
 					data = []testContent{
 						{
-							Fetch{"Woops!http://httpbin.org/range/15", 10},
-							ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("URL path is not accepted.\n")},
+							Fetch{URL: "Woops!http://httpbin.org/range/15", Interval: 10},
+							"", &failure.Validation{Field: "url", Msg: "URL path is not accepted."},
 						},
 						{
-							Fetch{"http://httpbin.Woops!org/range/15", 14},
-							ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("URL path is not accepted.\n")},
+							Fetch{URL: "http://httpbin.Woops!org/range/15", Interval: 14},
+							"", &failure.Validation{Field: "url", Msg: "URL path is not accepted."},
 						},
 						{
-							Fetch{"http://httpbin.org/range/15Woops!", 15},
-							ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("URL path is not accepted.\n")},
+							Fetch{URL: "http://httpbin.org/range/15Woops!", Interval: 15},
+							"", &failure.Validation{Field: "url", Msg: "URL path is not accepted."},
 						},
 						{
-							Fetch{"http://httpbin.org/delay/150", 0},
-							ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("Interval value must be greater than 0.\n")},
+							Fetch{URL: "http://httpbin.org/delay/150", Interval: 0},
+							"", &failure.Validation{Field: "interval", Msg: "Interval value must be greater than 0."},
 						},
 						{
-							Fetch{"https://httpbin.org/range/20", -10},
-							ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("Interval value must be greater than 0.\n")},
+							Fetch{URL: "https://httpbin.org/range/20", Interval: -10},
+							"", &failure.Validation{Field: "interval", Msg: "Interval value must be greater than 0."},
 						},
 						{
-							Fetch{"www.google.com", 12},
-							ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("URL path is not accepted.\n")},
+							Fetch{URL: "www.google.com", Interval: 12},
+							"", &failure.Validation{Field: "url", Msg: "URL path is not accepted."},
 						},
 						{
-							Fetch{"", 10},
-							ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("URL path is not accepted.\n")},
+							Fetch{URL: "", Interval: 10},
+							"", &failure.Validation{Field: "url", Msg: "URL path is not accepted."},
 						},
 						{
-							Fetch{"", -1},
-							ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("Interval and URL path are not accepted.\n")},
+							Fetch{URL: "", Interval: -1},
+							"", &failure.Validation{Field: "url", Msg: "Interval and URL path are not accepted."},
+						},
+						{
+							Fetch{URL: "https://httpbin.org/range/20", Interval: 10, Retention: &Retention{MaxRecords: -1}},
+							"", &failure.Validation{Field: "retention", Msg: "Retention max_records must be greater or equal 0."},
+						},
+						{
+							Fetch{URL: "https://httpbin.org/range/20", Interval: 10, Retention: &Retention{MaxAge: -60}},
+							"", &failure.Validation{Field: "retention", Msg: "Retention max_age must be greater or equal 0."},
+						},
+						{
+							Fetch{URL: "https://httpbin.org/range/20", Interval: 10, Tags: []string{"prod", "a b"}},
+							"", &failure.Validation{Field: "tags", Msg: "Tag 1: must match ^[a-zA-Z0-9_.:-]{1,64}$."},
+						},
+						{
+							Fetch{URL: "https://httpbin.org/range/20", Interval: 10, Tags: []string{"prod", "prod"}},
+							"", &failure.Validation{Field: "tags", Msg: "Tag 1: \"prod\" is already used."},
 						},
 					}
 				})
 
-				It("Should return ID: -1, http.StatusInternalServerError, and error msg.", func() {
+				It("Should return no ID and validation error.", func() {
 					for _, el := range data {
-						serviceVal := adder.CreateRecord(el.Fetch)
-						Expect(serviceVal.StorageKeyID).To(Equal(el.ServiceValidation.StorageKeyID))
-						Expect(serviceVal.Status).To(Equal(el.ServiceValidation.Status))
-						Expect(serviceVal.Msg).To(Equal(el.ServiceValidation.Msg))
+						id, err := adder.CreateRecord(el.Fetch)
+						expectResult(id, err, el)
 					}
 				})
 			})
 		})
+
+		Context("When fetch assertions are not valid.", func() {
+			BeforeEach(func() { // Configuration
+				url := "https://httpbin.org/range/15"
+				data = []testContent{
+					{
+						Fetch{URL: url, Interval: 10, Assertions: []Check{{Type: "woops"}}},
+						"", &failure.Validation{Field: "assertions", Msg: "Assertion 0: unknown type \"woops\"."},
+					},
+					{
+						Fetch{URL: url, Interval: 10, Assertions: []Check{{Type: CheckContains, Value: "abc"}, {Type: CheckRegex, Value: "a(b"}}},
+						"", &failure.Validation{Field: "assertions", Msg: "Assertion 1: value must be a valid regular expression."},
+					},
+					{
+						Fetch{URL: url, Interval: 10, Assertions: []Check{{Type: CheckNotContains}}},
+						"", &failure.Validation{Field: "assertions", Msg: "Assertion 0: value must not be empty."},
+					},
+					{
+						Fetch{URL: url, Interval: 10, Assertions: []Check{{Type: CheckJSONPathEq, Path: "status", Value: "ok"}}},
+						"", &failure.Validation{Field: "assertions", Msg: "Assertion 0: path must be a valid JSONPath."},
+					},
+					{
+						Fetch{URL: url, Interval: 10, Assertions: []Check{{Type: CheckMaxLatency, Latency: 0}}},
+						"", &failure.Validation{Field: "assertions", Msg: "Assertion 0: latency must be greater than 0."},
+					},
+				}
+			})
+
+			It("Should return no ID and assertion validation error.", func() {
+				for _, el := range data {
+					id, err := adder.CreateRecord(el.Fetch)
+					expectResult(id, err, el)
+				}
+			})
+		})
+
+		Context("When fetch extractors are not valid.", func() {
+			BeforeEach(func() { // Configuration
+				url := "https://httpbin.org/range/15"
+				data = []testContent{
+					{
+						Fetch{URL: url, Interval: 10, Extractors: []Extractor{{Name: "a b", Source: ExtractHeader, Expr: "Date"}}},
+						"", &failure.Validation{Field: "extractors", Msg: "Extractor 0: name must match ^[a-zA-Z0-9_.-]{1,64}$."},
+					},
+					{
+						Fetch{URL: url, Interval: 10, Extractors: []Extractor{{Name: "a", Source: ExtractHeader, Expr: "Date"}, {Name: "a", Source: ExtractHeader, Expr: "Age"}}},
+						"", &failure.Validation{Field: "extractors", Msg: "Extractor 1: name \"a\" is already used."},
+					},
+					{
+						Fetch{URL: url, Interval: 10, Extractors: []Extractor{{Name: "a", Source: "xpath", Expr: "/a"}}},
+						"", &failure.Validation{Field: "extractors", Msg: "Extractor 0: unknown source \"xpath\"."},
+					},
+					{
+						Fetch{URL: url, Interval: 10, Extractors: []Extractor{{Name: "a", Source: ExtractJSONPath, Expr: "$.a", Type: "int"}}},
+						"", &failure.Validation{Field: "extractors", Msg: "Extractor 0: unknown value type \"int\"."},
+					},
+					{
+						Fetch{URL: url, Interval: 10, Extractors: []Extractor{{Name: "a", Source: ExtractRegex, Expr: `v=(\d+)`, Group: 2}}},
+						"", &failure.Validation{Field: "extractors", Msg: "Extractor 0: group must be in range [0, 1]."},
+					},
+				}
+			})
+
+			It("Should return no ID and extractor validation error.", func() {
+				for _, el := range data {
+					id, err := adder.CreateRecord(el.Fetch)
+					expectResult(id, err, el)
+				}
+			})
+		})
+
+		Context("When tenant quota is exceeded.", func() {
+			BeforeEach(func() { // Configuration
+				fetchRep = FakeRepositoryAdder{Count: 3}
+				quotaRep = FakeRepositoryQuotas{Default: Quota{MaxFetches: 5, MinInterval: 30}}
+				data = []testContent{
+					{
+						Fetch{URL: "https://httpbin.org/range/15", Interval: 30, Tenant: "team-a"},
+						FakeID, nil,
+					},
+					{
+						Fetch{URL: "https://httpbin.org/range/15", Interval: 10, Tenant: "team-a"},
+						"", &failure.Validation{Field: "interval", Msg: "Interval value must be greater or equal 30."},
+					},
+					{
+						Fetch{URL: "https://httpbin.org/range/15", Interval: 30, Tenant: "Team A"},
+						"", &failure.Validation{Field: "tenant", Msg: "Tenant name is not valid."},
+					},
+				}
+			})
+
+			AfterEach(func() {
+				fetchRep = FakeRepositoryAdder{}
+				quotaRep = FakeRepositoryQuotas{}
+			})
+
+			It("Should return no ID and quota error.", func() {
+				for _, el := range data {
+					id, err := adder.CreateRecord(el.Fetch)
+					expectResult(id, err, el)
+				}
+
+				fetchRep.Count = 5
+				_, err := adder.CreateRecord(data[0].Fetch)
+				Expect(err).To(Equal(&failure.Quota{Msg: "Tenant fetches quota of 5 has been exceeded."}))
+			})
+		})
+
+		Context("When global limits are reached.", func() {
+			BeforeEach(func() { // Configuration
+				quotaRep = FakeRepositoryQuotas{Global: Limits{MaxActive: 10, MaxRate: 1}}
+			})
+
+			AfterEach(func() {
+				fetchRep = FakeRepositoryAdder{}
+				quotaRep = FakeRepositoryQuotas{}
+			})
+
+			It("Should return busy error with retry delay.", func() {
+				fetch := Fetch{URL: "https://httpbin.org/range/15", Interval: 2}
+
+				fetchRep = FakeRepositoryAdder{Count: 9, Rate: 0.5}
+				_, err := adder.CreateRecord(fetch)
+				Expect(err).NotTo(HaveOccurred())
+
+				fetchRep = FakeRepositoryAdder{Count: 9, Rate: 0.75}
+				_, err = adder.CreateRecord(fetch)
+				Expect(err).To(Equal(&failure.Busy{Msg: "Server limit of 1 fetches per second has been reached.", RetryAfter: RetryAfter}))
+
+				fetchRep = FakeRepositoryAdder{Count: 10}
+				_, err = adder.CreateRecord(fetch)
+				Expect(err).To(Equal(&failure.Busy{Msg: "Server limit of 10 active fetches has been reached.", RetryAfter: RetryAfter}))
+			})
+
+			It("Should not update fetch to exceed the rate.", func() {
+				fetchRep = FakeRepositoryAdder{Count: 10, Rate: 0.75, Intervals: map[string]int{FakeID: 4}}
+				fetch := Fetch{URL: "https://httpbin.org/range/15", Interval: 2}
+				Expect(adder.UpdateRecord("", FakeID, fetch)).To(Succeed()) // 0.75 - 0.25 + 0.5
+
+				fetch.Interval = 1
+				Expect(adder.UpdateRecord("", FakeID, fetch)).To(Equal(&failure.Busy{Msg: "Server limit of 1 fetches per second has been reached.", RetryAfter: RetryAfter}))
+
+				fetchRep.Rate = 1.5 // over the limit already
+				fetch.Interval = 8
+				Expect(adder.UpdateRecord("", FakeID, fetch)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("When calling CreateRecords", func() {
+		var (
+			adder    Service
+			fetchRep FakeRepositoryAdder
+			quotaRep FakeRepositoryQuotas
+			batch    []Fetch
+		)
+
+		BeforeEach(func() { // Configuration
+			fetchRep = FakeRepositoryAdder{}
+			quotaRep = FakeRepositoryQuotas{}
+			batch = []Fetch{
+				{URL: "https://httpbin.org/range/15", Interval: 10},
+				{URL: "Woops!", Interval: 10},
+				{URL: "https://httpbin.org/delay/1", Interval: 20},
+			}
+		})
+
+		JustBeforeEach(func() {
+			adder = NewService(&fetchRep, &quotaRep) // Creation
+		})
+
+		Context("When batch is not atomic.", func() {
+			It("Should create valid fetches and report invalid ones.", func() {
+				results := adder.CreateRecords(batch, false)
+				Expect(results).To(Equal([]Result{
+					{ID: FakeID, Err: nil},
+					{Err: &failure.Validation{Field: "url", Msg: "URL path is not accepted."}},
+					{ID: FakeID, Err: nil},
+				}))
+			})
+		})
+
+		Context("When batch is atomic.", func() {
+			It("Should abort valid fetches if any fails.", func() {
+				results := adder.CreateRecords(batch, true)
+				aborted := &failure.Aborted{Msg: "Fetch not created, other fetch of the batch failed."}
+				Expect(results).To(Equal([]Result{
+					{Err: aborted},
+					{Err: &failure.Validation{Field: "url", Msg: "URL path is not accepted."}},
+					{Err: aborted},
+				}))
+
+				results = adder.CreateRecords([]Fetch{batch[0], batch[2]}, true)
+				Expect(results).To(Equal([]Result{{ID: FakeID}, {ID: FakeID}}))
+			})
+		})
+
+		Context("When batch exceeds tenant quota.", func() {
+			BeforeEach(func() {
+				fetchRep = FakeRepositoryAdder{Count: 1}
+				quotaRep = FakeRepositoryQuotas{Default: Quota{MaxFetches: 2}}
+			})
+
+			It("Should count fetches of the batch into quota.", func() {
+				results := adder.CreateRecords([]Fetch{batch[0], batch[2]}, false)
+				Expect(results[0].Err).NotTo(HaveOccurred())
+				Expect(results[1].Err).To(Equal(&failure.Quota{Msg: "Tenant fetches quota of 2 has been exceeded."}))
+			})
+		})
+
+		Context("When batch fetches share name.", func() {
+			It("Should create the first one only.", func() {
+				batch[0].Name, batch[2].Name = "front", "front"
+				results := adder.CreateRecords([]Fetch{batch[0], batch[2]}, false)
+				Expect(results).To(Equal([]Result{
+					{ID: FakeID, Err: nil},
+					{Err: &failure.Conflict{Msg: `Fetch name "front" is already used.`}},
+				}))
+			})
+		})
+	})
+
+	Describe("When naming fetches", func() {
+		const front, other = "01HZX3V6Q8J5K2M9N4P7R1S3T6", "01HZX3V6Q8J5K2M9N4P7R1S3T7"
+
+		var (
+			adder    Service
+			fetchRep FakeRepositoryAdder
+			quotaRep FakeRepositoryQuotas
+			record   Fetch
+		)
+
+		BeforeEach(func() { // Configuration
+			fetchRep = FakeRepositoryAdder{Names: map[string]string{"front": front}}
+			quotaRep = FakeRepositoryQuotas{Default: Quota{MinInterval: 10}}
+			record = Fetch{URL: "https://httpbin.org/range/15", Interval: 10}
+		})
+
+		JustBeforeEach(func() {
+			adder = NewService(&fetchRep, &quotaRep) // Creation
+		})
+
+		Context("When name is not well formed.", func() {
+			It("Should reject the fetch.", func() {
+				record.Name = "front page"
+				_, err := adder.CreateRecord(record)
+				Expect(err).To(Equal(&failure.Validation{Field: "name", Msg: "Name must match ^[a-zA-Z0-9_.-]{1,64}$."}))
+
+				record.Name = other
+				_, err = adder.CreateRecord(record)
+				Expect(err).To(Equal(&failure.Validation{Field: "name", Msg: "Name must not have form of fetch ID."}))
+
+				record.Name = "export"
+				_, err = adder.CreateRecord(record)
+				Expect(err).To(Equal(&failure.Validation{Field: "name", Msg: `Name "export" is reserved.`}))
+				Expect(adder.UpdateRecord("", other, Fetch{URL: record.URL, Interval: 10, Name: "test"})).To(Equal(&failure.Validation{Field: "name", Msg: `Name "test" is reserved.`}))
+			})
+		})
+
+		Context("When name is used by other fetch.", func() {
+			It("Should reject creating and updating the fetch.", func() {
+				record.Name = "front"
+				_, err := adder.CreateRecord(record)
+				Expect(err).To(Equal(&failure.Conflict{Msg: `Fetch name "front" is already used.`}))
+				Expect(adder.UpdateRecord("", other, record)).To(Equal(&failure.Conflict{Msg: `Fetch name "front" is already used.`}))
+			})
+		})
+
+		Context("When updating fetch keeping its name.", func() {
+			It("Should check tenant minimum interval.", func() {
+				record.Name = "front"
+				Expect(adder.UpdateRecord("", front, record)).To(Succeed())
+				record.Interval = 5
+				Expect(adder.UpdateRecord("", front, record)).To(Equal(&failure.Validation{Field: "interval", Msg: "Interval value must be greater or equal 10."}))
+			})
+		})
+	})
+
+	Describe("When pausing fetches", func() {
+		var adder Service
+
+		BeforeEach(func() { // Creation
+			adder = NewService(new(FakeRepositoryAdder), new(FakeRepositoryQuotas))
+		})
+
+		It("Should pause and resume existing fetch.", func() {
+			Expect(adder.PauseRecord("default", FakeID, time.Time{})).To(Succeed())
+			Expect(adder.PauseRecord("default", FakeID, time.Now().Add(time.Hour))).To(Succeed())
+			adder = NewService(&FakeRepositoryAdder{Paused: map[string]bool{FakeID: true}}, new(FakeRepositoryQuotas))
+			Expect(adder.ResumeRecord("default", FakeID)).To(Succeed())
+		})
+
+		It("Should reject resuming fetch which is not paused.", func() {
+			err := adder.ResumeRecord("default", FakeID)
+			Expect(err).To(Equal(&failure.Conflict{Msg: `Fetch "` + FakeID + `" is not paused.`}))
+		})
+
+		It("Should reject pause end in the past and missing fetch.", func() {
+			err := adder.PauseRecord("default", FakeID, time.Now().Add(-time.Second))
+			Expect(err).To(Equal(&failure.Validation{Field: "until", Msg: "Pause end must be in the future."}))
+
+			err = adder.PauseRecord("default", "01HZX3V6Q8J5K2M9N4P7R1S3T6", time.Time{})
+			Expect(err).To(Equal(&failure.NotFound{Msg: `Fetch "01HZX3V6Q8J5K2M9N4P7R1S3T6" not found.`}))
+			err = adder.ResumeRecord("default", "01HZX3V6Q8J5K2M9N4P7R1S3T6")
+			Expect(err).To(BeAssignableToTypeOf(&failure.NotFound{}))
+		})
+	})
+
+	Describe("When calling FindOrCreateRecord", func() {
+		const existing = "01HZX3V6Q8J5K2M9N4P7R1S3T6"
+
+		var (
+			adder    Service
+			fetchRep FakeRepositoryAdder
+			quotaRep FakeRepositoryQuotas
+		)
+
+		BeforeEach(func() { // Configuration
+			fetchRep = FakeRepositoryAdder{Count: 1, URLs: map[string]string{"https://httpbin.org/range/15": existing}}
+			quotaRep = FakeRepositoryQuotas{Default: Quota{MaxFetches: 1}}
+		})
+
+		JustBeforeEach(func() {
+			adder = NewService(&fetchRep, &quotaRep) // Creation
+		})
+
+		Context("When fetch of the same URL exists.", func() {
+			It("Should return it without checking quota.", func() {
+				id, created, err := adder.FindOrCreateRecord(Fetch{URL: "https://httpbin.org/range/15", Interval: 30})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeFalse())
+				Expect(id).To(Equal(existing))
+			})
+
+			It("Should compare normalised URLs.", func() {
+				Expect(NormalURL("HTTPS://HttpBin.org:443/range/15#top")).To(Equal("https://httpbin.org/range/15"))
+				Expect(NormalURL("http://httpbin.org:80")).To(Equal("http://httpbin.org/"))
+				Expect(NormalURL("http://httpbin.org:443/range/15")).To(Equal("http://httpbin.org:443/range/15"))
+			})
+		})
+
+		Context("When there is no such fetch.", func() {
+			It("Should create it within quota.", func() {
+				_, created, err := adder.FindOrCreateRecord(Fetch{URL: "https://httpbin.org/range/16", Interval: 30})
+				Expect(err).To(Equal(&failure.Quota{Msg: "Tenant fetches quota of 1 has been exceeded."}))
+				Expect(created).To(BeFalse())
+
+				quotaRep.Default.MaxFetches = 2
+				id, created, err := adder.FindOrCreateRecord(Fetch{URL: "https://httpbin.org/range/16", Interval: 30})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+				Expect(id).To(Equal(FakeID))
+			})
+		})
+
+		Context("When definition is not valid.", func() {
+			It("Should reject it before matching.", func() {
+				_, _, err := adder.FindOrCreateRecord(Fetch{URL: "https://httpbin.org/range/15"})
+				Expect(err).To(Equal(&failure.Validation{Field: "interval", Msg: "Interval value must be greater than 0."}))
+			})
+		})
 	})
 })
@@ -0,0 +1,32 @@
+package adding
+
+import (
+	"regexp"
+
+	"github.com/gobuzz/pkg/domain/failure"
+)
+
+// MaxTags limits number of tags of a single fetch.
+const MaxTags = 16
+
+// tagPattern limits tags to characters safe in URL query.
+var tagPattern = regexp.MustCompile(`^[a-zA-Z0-9_.:-]{1,64}$`)
+
+// validateTags reports whether tags are well formed and unique.
+func validateTags(tags []string) error {
+	if len(tags) > MaxTags {
+		return failure.Invalid("tags", "Fetch must not have more than %d tags.", MaxTags)
+	}
+
+	seen := make(map[string]bool, len(tags))
+	for i, t := range tags {
+		switch {
+		case !tagPattern.MatchString(t):
+			return failure.Invalid("tags", "Tag %d: must match %s.", i, tagPattern)
+		case seen[t]:
+			return failure.Invalid("tags", "Tag %d: %q is already used.", i, t)
+		}
+		seen[t] = true
+	}
+	return nil
+}
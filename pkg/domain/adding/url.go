@@ -0,0 +1,27 @@
+package adding
+
+import (
+	"net/url"
+	"strings"
+)
+
+// NormalURL returns fetched URL in form which compares equal for the
+// same upstream request: scheme and host are lower cased, default port
+// and fragment dropped and empty path is "/". URL which does not parse
+// is returned as is.
+func NormalURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); port == "80" && u.Scheme == "http" || port == "443" && u.Scheme == "https" {
+		u.Host = u.Hostname()
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.Fragment, u.RawFragment = "", ""
+	return u.String()
+}
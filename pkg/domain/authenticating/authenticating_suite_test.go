@@ -0,0 +1,13 @@
+package authenticating_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAuthenticating(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Authenticating Service Suite")
+}
@@ -0,0 +1,49 @@
+package authenticating
+
+import (
+	"context"
+	"time"
+)
+
+// Supported API key scopes.
+const (
+	ScopeFetchersRead  = "fetchers:read"
+	ScopeFetchersWrite = "fetchers:write"
+	ScopeAdmin         = "admin" // grants all scopes
+)
+
+// Key represents API key used by client for authentication. Only hash
+// of the key secret is kept. Fetchers created with the key belong to
+// its tenant.
+type Key struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Tenant    string    `json:"tenant"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	Revoked   bool      `json:"revoked"`
+	Hash      [32]byte  `json:"-"`
+}
+
+// HasScope reports whether key grants scope. Admin scope grants all.
+func (k Key) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+type ctxKey struct{}
+
+// WithKey returns copy of ctx carrying authenticated key.
+func WithKey(ctx context.Context, key Key) context.Context {
+	return context.WithValue(ctx, ctxKey{}, key)
+}
+
+// KeyFrom returns authenticated key carried by ctx if any.
+func KeyFrom(ctx context.Context) (Key, bool) {
+	key, ok := ctx.Value(ctxKey{}).(Key)
+	return key, ok
+}
@@ -0,0 +1,52 @@
+package authenticating
+
+// FakeRepositoryKeys defines RepositoryKeys mock keeping keys in map.
+type FakeRepositoryKeys struct {
+	keys map[string]Key
+}
+
+// CreateKey implements RepositoryKeys interface.
+func (f *FakeRepositoryKeys) CreateKey(key Key) bool {
+	if f.keys == nil {
+		f.keys = make(map[string]Key)
+	}
+	if _, ok := f.KeyByHash(key.Hash); ok {
+		return false
+	}
+	f.keys[key.ID] = key
+	return true
+}
+
+// UpdateKey implements RepositoryKeys interface.
+func (f *FakeRepositoryKeys) UpdateKey(key Key) bool {
+	if _, ok := f.keys[key.ID]; !ok {
+		return false
+	}
+	f.keys[key.ID] = key
+	return true
+}
+
+// Key implements RepositoryKeys interface.
+func (f *FakeRepositoryKeys) Key(id string) (Key, bool) {
+	key, ok := f.keys[id]
+	return key, ok
+}
+
+// KeyByHash implements RepositoryKeys interface.
+func (f *FakeRepositoryKeys) KeyByHash(hash [32]byte) (Key, bool) {
+	for _, key := range f.keys {
+		if key.Hash == hash {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// Keys implements RepositoryKeys interface.
+func (f *FakeRepositoryKeys) Keys() []Key {
+	keys := make([]Key, 0, len(f.keys))
+	for _, key := range f.keys {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,153 @@
+package authenticating
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+)
+
+// secretPrefix marks gobuzz API key secrets.
+const secretPrefix = "gbz_"
+
+// RepositoryKeys provides storing functionality of API keys repository.
+type RepositoryKeys interface {
+	CreateKey(key Key) bool
+	UpdateKey(key Key) bool
+	Key(id string) (Key, bool)
+	KeyByHash(hash [32]byte) (Key, bool)
+	Keys() []Key
+}
+
+// Service defines RepositoryKeys operation.
+type Service struct {
+	keysRep RepositoryKeys
+}
+
+// CreateKey generates new API key of tenant with given scopes. Secret of
+// the key is returned only once, repository keeps its hash. Empty tenant
+// means adding.DefaultTenant.
+func (s *Service) CreateKey(name, tenant string, scopes []string) (Key, string, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return Key{}, "", err
+	}
+	key, err := s.RegisterKey(name, tenant, secret, scopes)
+	if err != nil {
+		return Key{}, "", err
+	}
+	return key, secret, nil
+}
+
+// RegisterKey adds API key with known secret, e.g. bootstrap admin key
+// provided by server configuration.
+func (s *Service) RegisterKey(name, tenant, secret string, scopes []string) (Key, error) {
+	if tenant == "" {
+		tenant = adding.DefaultTenant
+	}
+
+	// Validation logic...
+	switch {
+	case name == "":
+		return Key{}, failure.Invalid("name", "Key name must not be empty.")
+	case !adding.ValidTenant(tenant):
+		return Key{}, failure.Invalid("tenant", "Tenant name is not valid.")
+	case len(secret) < 16:
+		return Key{}, failure.Invalid("key", "Key secret must be at least 16 characters long.")
+	case len(scopes) == 0:
+		return Key{}, failure.Invalid("scopes", "Key must have at least one scope.")
+	}
+	for _, scope := range scopes {
+		if scope != ScopeFetchersRead && scope != ScopeFetchersWrite && scope != ScopeAdmin {
+			return Key{}, failure.Invalid("scopes", "Scope %q is not supported.", scope)
+		}
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Key{}, err
+	}
+
+	key := Key{ID: id, Name: name, Tenant: tenant, Scopes: scopes, CreatedAt: time.Now().UTC(), Hash: hash(secret)}
+	if !s.keysRep.CreateKey(key) {
+		return Key{}, &failure.Conflict{Msg: "Key with the same secret already exists."}
+	}
+	return key, nil
+}
+
+// RotateKey replaces secret of API key with given ID. Previous secret
+// stops working immediately.
+func (s *Service) RotateKey(id string) (Key, string, error) {
+	key, ok := s.keysRep.Key(id)
+	if !ok || key.Revoked {
+		return Key{}, "", failure.Missing("Key %q not found.", id)
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return Key{}, "", err
+	}
+
+	key.Hash = hash(secret)
+	s.keysRep.UpdateKey(key)
+	return key, secret, nil
+}
+
+// RevokeKey disables API key with given ID.
+func (s *Service) RevokeKey(id string) error {
+	key, ok := s.keysRep.Key(id)
+	if !ok || key.Revoked {
+		return failure.Missing("Key %q not found.", id)
+	}
+
+	key.Revoked = true
+	s.keysRep.UpdateKey(key)
+	return nil
+}
+
+// Keys returns all API keys including revoked ones.
+func (s *Service) Keys() []Key {
+	return s.keysRep.Keys()
+}
+
+// Authenticate returns API key matching the secret. Revoked and
+// unknown keys are reported with failure.Unauthorized error.
+func (s *Service) Authenticate(secret string) (Key, error) {
+	key, ok := s.keysRep.KeyByHash(hash(secret))
+	if secret == "" || !ok || key.Revoked {
+		return Key{}, &failure.Unauthorized{Msg: "Missing or invalid API key."}
+	}
+	return key, nil
+}
+
+// NewService creates an authenticating service with the necessary dependencies.
+func NewService(r RepositoryKeys) Service {
+	return Service{r}
+}
+
+// hash returns digest of the secret kept at rest. Secrets are random
+// high entropy strings, so a fast hash is sufficient.
+func hash(secret string) [32]byte {
+	return sha256.Sum256([]byte(secret))
+}
+
+// newSecret returns random API key secret.
+func newSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// newID returns random API key identifier.
+func newID() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "key_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,92 @@
+package authenticating_test
+
+import (
+	. "github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/failure"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("The authenticating service", func() {
+	var (
+		auth    Service
+		keysRep *FakeRepositoryKeys
+	)
+
+	BeforeEach(func() { // Configuration
+		keysRep = new(FakeRepositoryKeys)
+	})
+
+	JustBeforeEach(func() {
+		auth = NewService(keysRep) // Creation
+	})
+
+	Describe("When calling CreateKey", func() {
+		It("Should return key which secret authenticates.", func() {
+			key, secret, err := auth.CreateKey("ci", "", []string{ScopeFetchersRead})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret).To(HavePrefix("gbz_"))
+			Expect(key.Hash).NotTo(Equal([32]byte{}))
+
+			authKey, err := auth.Authenticate(secret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authKey.ID).To(Equal(key.ID))
+			Expect(authKey.HasScope(ScopeFetchersRead)).To(BeTrue())
+			Expect(authKey.HasScope(ScopeFetchersWrite)).To(BeFalse())
+			Expect(authKey.Tenant).To(Equal("default"))
+		})
+
+		It("Should bind key to given tenant.", func() {
+			key, _, err := auth.CreateKey("ci", "team-a", []string{ScopeFetchersRead})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.Tenant).To(Equal("team-a"))
+
+			_, _, err = auth.CreateKey("ci", "Team A", []string{ScopeFetchersRead})
+			Expect(err).To(MatchError("Tenant name is not valid."))
+			Expect(err).To(BeAssignableToTypeOf(&failure.Validation{}))
+		})
+
+		It("Should return validation error for invalid name or scopes.", func() {
+			_, _, err := auth.CreateKey("", "", []string{ScopeAdmin})
+			Expect(err).To(MatchError("Key name must not be empty."))
+			Expect(err).To(BeAssignableToTypeOf(&failure.Validation{}))
+
+			_, _, err = auth.CreateKey("ci", "", nil)
+			Expect(err).To(MatchError("Key must have at least one scope."))
+			Expect(err).To(BeAssignableToTypeOf(&failure.Validation{}))
+
+			_, _, err = auth.CreateKey("ci", "", []string{"root"})
+			Expect(err).To(MatchError("Scope \"root\" is not supported."))
+			Expect(err).To(BeAssignableToTypeOf(&failure.Validation{}))
+		})
+	})
+
+	Describe("When calling RotateKey and RevokeKey", func() {
+		It("Should invalidate previous secret.", func() {
+			key, oldSecret, _ := auth.CreateKey("ci", "", []string{ScopeAdmin})
+			_, newSecret, err := auth.RotateKey(key.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newSecret).NotTo(Equal(oldSecret))
+
+			_, err = auth.Authenticate(oldSecret)
+			Expect(err).To(BeAssignableToTypeOf(&failure.Unauthorized{}))
+			_, err = auth.Authenticate(newSecret)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(auth.RevokeKey(key.ID)).To(Succeed())
+			_, err = auth.Authenticate(newSecret)
+			Expect(err).To(BeAssignableToTypeOf(&failure.Unauthorized{}))
+			Expect(auth.RevokeKey(key.ID)).To(BeAssignableToTypeOf(&failure.NotFound{}))
+		})
+	})
+
+	Describe("When calling Authenticate", func() {
+		It("Should return unauthorized error for unknown secret.", func() {
+			for _, secret := range []string{"", "gbz_woops"} {
+				_, err := auth.Authenticate(secret)
+				Expect(err).To(BeAssignableToTypeOf(&failure.Unauthorized{}))
+				Expect(err).To(MatchError("Missing or invalid API key."))
+			}
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package compacting_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCompacting(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Compacting Service Suite")
+}
@@ -0,0 +1,12 @@
+package compacting
+
+import "time"
+
+// FakeRepositoryCompactor defines Compact mock reclaiming
+// 100 bytes from a single record on each call.
+type FakeRepositoryCompactor struct{}
+
+// Compact implements RepositoryCompactor interface.
+func (f *FakeRepositoryCompactor) Compact(global Policy, now time.Time) Report {
+	return Report{LastRun: now, RemovedRecords: 1, ReclaimedBytes: 100, StoredBytes: 1000}
+}
@@ -0,0 +1,33 @@
+package compacting
+
+import "time"
+
+// Policy defines rules for keeping response history. Zero value
+// of a field means no limit.
+type Policy struct {
+	MaxRecords int           // keep last N records
+	MaxAge     time.Duration // keep records newer than
+	MaxBytes   int           // cap of stored body bytes
+}
+
+// Report describes space reclaimed by compaction.
+type Report struct {
+	Runs           int       `json:"runs"`
+	LastRun        time.Time `json:"last_run"`
+	RemovedRecords int       `json:"removed_records"`
+	RemovedPoints  int       `json:"removed_points"`
+	DedupedBodies  int       `json:"deduped_bodies"`
+	ReclaimedBytes int       `json:"reclaimed_bytes"`
+	StoredBytes    int       `json:"stored_bytes"`
+}
+
+// add accumulates single compaction run results into total report.
+func (r *Report) add(run Report) {
+	r.Runs++
+	r.LastRun = run.LastRun
+	r.RemovedRecords += run.RemovedRecords
+	r.RemovedPoints += run.RemovedPoints
+	r.DedupedBodies += run.DedupedBodies
+	r.ReclaimedBytes += run.ReclaimedBytes
+	r.StoredBytes = run.StoredBytes
+}
@@ -0,0 +1,62 @@
+package compacting
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// RepositoryCompactor provides compaction functionality of response
+// repository. Global policy is applied on top of per fetch policies.
+type RepositoryCompactor interface {
+	Compact(global Policy, now time.Time) Report
+}
+
+// Service defines RepositoryCompactor operation.
+type Service struct {
+	respRep RepositoryCompactor
+	global  Policy
+	mu      *sync.Mutex
+	total   *Report
+}
+
+// Run performs single compaction of response repository and
+// returns space reclaimed by this run.
+func (s *Service) Run() Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run := s.respRep.Compact(s.global, time.Now())
+	s.total.add(run)
+	return run
+}
+
+// Report returns space reclaimed by all compaction runs so far.
+func (s *Service) Report() Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.total
+}
+
+// Start is a background compactor running compaction every interval
+// until stop channel is closed.
+func (s *Service) Start(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			run := s.Run()
+			log.Printf("Compactor: removed %d records and %d points, deduped %d bodies, reclaimed %d bytes.\n",
+				run.RemovedRecords, run.RemovedPoints, run.DedupedBodies, run.ReclaimedBytes)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// NewService creates a compacting service with the necessary dependencies.
+func NewService(r RepositoryCompactor, global Policy) Service {
+	return Service{respRep: r, global: global, mu: new(sync.Mutex), total: new(Report)}
+}
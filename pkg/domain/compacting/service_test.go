@@ -0,0 +1,33 @@
+package compacting_test
+
+import (
+	. "github.com/gobuzz/pkg/domain/compacting"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("The compacting service", func() {
+	var (
+		compactor Service
+		fakeRep   FakeRepositoryCompactor
+	)
+
+	JustBeforeEach(func() {
+		compactor = NewService(&fakeRep, Policy{}) // Creation
+	})
+
+	Describe("When calling Run", func() {
+		It("Should return report of single run and accumulate total report.", func() {
+			for i := 0; i < 3; i++ {
+				run := compactor.Run()
+				Expect(run.ReclaimedBytes).To(Equal(100))
+			}
+
+			total := compactor.Report()
+			Expect(total.Runs).To(Equal(3))
+			Expect(total.RemovedRecords).To(Equal(3))
+			Expect(total.ReclaimedBytes).To(Equal(300))
+			Expect(total.StoredBytes).To(Equal(1000))
+		})
+	})
+})
@@ -0,0 +1,81 @@
+// Package failure defines errors shared by domain services. Adapters map
+// them to their own status codes, services stay transport agnostic.
+package failure
+
+import (
+	"fmt"
+	"time"
+)
+
+// Validation reports invalid input. Field names the offending input if
+// known.
+type Validation struct {
+	Field string
+	Msg   string
+}
+
+func (e *Validation) Error() string { return e.Msg }
+
+// NotFound reports missing entity.
+type NotFound struct {
+	Msg string
+}
+
+func (e *NotFound) Error() string { return e.Msg }
+
+// Conflict reports entity clashing with existing one.
+type Conflict struct {
+	Msg string
+}
+
+func (e *Conflict) Error() string { return e.Msg }
+
+// Unauthorized reports missing or invalid credentials.
+type Unauthorized struct {
+	Msg string
+}
+
+func (e *Unauthorized) Error() string { return e.Msg }
+
+// Quota reports exceeded per tenant quota. Retrying does not help
+// until quota is raised.
+type Quota struct {
+	Msg string
+}
+
+func (e *Quota) Error() string { return e.Msg }
+
+// Busy reports reached server capacity. Request may succeed after
+// RetryAfter.
+type Busy struct {
+	Msg        string
+	RetryAfter time.Duration
+}
+
+func (e *Busy) Error() string { return e.Msg }
+
+// Aborted reports operation not done because other part of the same
+// all-or-nothing batch failed.
+type Aborted struct {
+	Msg string
+}
+
+func (e *Aborted) Error() string { return e.Msg }
+
+// Mismatch reports request which differs from the earlier one it claims
+// to repeat, e.g. idempotency key reused with other payload.
+type Mismatch struct {
+	Msg string
+}
+
+func (e *Mismatch) Error() string { return e.Msg }
+
+// Invalid returns Validation error of field with formatted message.
+func Invalid(field, format string, a ...interface{}) error {
+	return &Validation{Field: field, Msg: fmt.Sprintf(format, a...)}
+}
+
+// Missing returns NotFound error with formatted message.
+func Missing(format string, a ...interface{}) error {
+	return &NotFound{Msg: fmt.Sprintf(format, a...)}
+}
@@ -0,0 +1,39 @@
+package listing
+
+import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+)
+
+// Fetch is a single fetch definition read from fetch repository. IDs
+// are globally unique ULIDs, Seq orders fetches of tenant by creation.
+// Paused fetch is not run until resumed or until PausedUntil if set.
+type Fetch struct {
+	ID          string             `json:"id"`
+	Seq         int                `json:"-"`
+	Name        string             `json:"name,omitempty"`
+	URL         string             `json:"url"`
+	Interval    int                `json:"interval"`
+	Assertions  []adding.Check     `json:"assertions,omitempty"`
+	Extractors  []adding.Extractor `json:"extractors,omitempty"`
+	Retention   *adding.Retention  `json:"retention,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+	Paused      bool               `json:"paused"`
+	PausedUntil *time.Time         `json:"paused_until,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// Definition returns definition of tenant fetch as it is created.
+func (f Fetch) Definition(tenant string) adding.Fetch {
+	return adding.Fetch{
+		Tenant:     tenant,
+		Name:       f.Name,
+		URL:        f.URL,
+		Interval:   f.Interval,
+		Assertions: f.Assertions,
+		Extractors: f.Extractors,
+		Retention:  f.Retention,
+		Tags:       f.Tags,
+	}
+}
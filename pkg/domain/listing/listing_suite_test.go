@@ -0,0 +1,13 @@
+package listing_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestListing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Listing Service Suite")
+}
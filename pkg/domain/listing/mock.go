@@ -0,0 +1,107 @@
+package listing
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+)
+
+// FakeRepositoryLister defines RepositoryLister mock. Series returns
+// points stored under Points field for any ID and name "value",
+// History returns Responses for fetch with ID adding.FakeID in Seq
+// order, ignoring time range and sort. Only default tenant has any data.
+type FakeRepositoryLister struct {
+	Points    []Point
+	Responses []Response
+}
+
+// History implements RepositoryLister interface.
+func (f *FakeRepositoryLister) History(tenant, id string, q HistoryQuery) ([]Response, bool) {
+	if tenant != adding.DefaultTenant || id != adding.FakeID {
+		return nil, false
+	}
+
+	history := []Response{}
+	for _, r := range f.Responses {
+		if q.After != nil && r.Seq <= q.After.Seq || q.Outcome != "" && r.Outcome != q.Outcome {
+			continue
+		}
+		if len(history) == q.Limit {
+			break
+		}
+		history = append(history, r)
+	}
+	return history, true
+}
+
+// Series implements RepositoryLister interface.
+func (f *FakeRepositoryLister) Series(tenant, id, name string, from, to time.Time) (Series, bool) {
+	if tenant != adding.DefaultTenant || name != "value" {
+		return Series{}, false
+	}
+
+	series := Series{Name: name, Type: "number", Points: []Point{}}
+	for _, p := range f.Points {
+		if !p.At.Before(from) && !p.At.After(to) {
+			series.Points = append(series.Points, p)
+		}
+	}
+	return series, true
+}
+
+// FakeRepositoryFetches defines RepositoryFetches mock. Fetches returns
+// Records field filtered by URL and Tag in Seq order, ignoring time
+// range and sort. Only default tenant has any data.
+type FakeRepositoryFetches struct {
+	Records []Fetch
+}
+
+// Fetch implements RepositoryFetches interface.
+func (f *FakeRepositoryFetches) Fetch(tenant, id string) (Fetch, bool) {
+	for _, fetch := range f.Records {
+		if tenant == adding.DefaultTenant && fetch.ID == id {
+			return fetch, true
+		}
+	}
+	return Fetch{}, false
+}
+
+// Fetches implements RepositoryFetches interface.
+func (f *FakeRepositoryFetches) Fetches(tenant string, q FetchQuery) []Fetch {
+	fetches := []Fetch{}
+	if tenant != adding.DefaultTenant {
+		return fetches
+	}
+
+	for _, fetch := range f.Records {
+		if q.After != nil && fetch.Seq <= q.After.Seq || !strings.Contains(fetch.URL, q.URL) || q.Tag != "" && !hasTag(fetch.Tags, q.Tag) {
+			continue
+		}
+		if len(fetches) == q.Limit {
+			break
+		}
+		fetches = append(fetches, fetch)
+	}
+	return fetches
+}
+
+// FindRecord implements RepositoryFetches interface.
+func (f *FakeRepositoryFetches) FindRecord(tenant, name string) (string, bool) {
+	for _, fetch := range f.Records {
+		if tenant == adding.DefaultTenant && fetch.Name == name {
+			return fetch.ID, true
+		}
+	}
+	return "", false
+}
+
+// hasTag reports whether tags contain tag.
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
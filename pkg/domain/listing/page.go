@@ -0,0 +1,114 @@
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/failure"
+)
+
+// Supported listing sort keys.
+const (
+	SortCreated  = "created_at" // default
+	SortDuration = "duration"   // history only
+)
+
+// Page size limits.
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Position marks the last item of a page in sorted listing. Value is
+// the sort key of the item (Unix seconds or duration), Seq breaks ties
+// between items with equal Value.
+type Position struct {
+	Value float64
+	Seq   int
+}
+
+// Page defines requested page of sorted listing. Cursor is returned
+// with the previous page, empty Cursor requests the first page.
+type Page struct {
+	Limit  int
+	Cursor string
+	Desc   bool
+}
+
+// HistoryQuery defines filters and sorting of response history. Zero
+// From or To means the time range is not bounded on that side, empty
+// Outcome matches any outcome.
+type HistoryQuery struct {
+	Page
+	From    time.Time
+	To      time.Time
+	Outcome string
+	Sort    string
+
+	After *Position // decoded Cursor, set by Service for repository
+}
+
+// FetchQuery defines filters of fetch list sorted by creation. URL
+// matches fetches containing it, Tag matches fetches having it.
+type FetchQuery struct {
+	Page
+	From time.Time
+	To   time.Time
+	URL  string
+	Tag  string
+
+	After *Position // decoded Cursor, set by Service for repository
+}
+
+// cursor is an opaque continuation token of listing. Sort and Desc
+// bind it to the listing order it was issued for.
+type cursor struct {
+	Sort  string  `json:"s"`
+	Desc  bool    `json:"d,omitempty"`
+	Value float64 `json:"v"`
+	Seq   int     `json:"i"`
+}
+
+// encodeCursor returns cursor pointing after position p.
+func encodeCursor(sort string, desc bool, p Position) string {
+	b, _ := json.Marshal(cursor{Sort: sort, Desc: desc, Value: p.Value, Seq: p.Seq})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor returns position of page cursor issued for the same
+// sort order. Returns nil position for empty cursor.
+func decodeCursor(page Page, sort string) (*Position, error) {
+	if page.Cursor == "" {
+		return nil, nil
+	}
+
+	var c cursor
+	b, err := base64.RawURLEncoding.DecodeString(page.Cursor)
+	if err == nil {
+		err = json.Unmarshal(b, &c)
+	}
+	switch {
+	case err != nil:
+		return nil, failure.Invalid("cursor", "Cursor is not valid.")
+	case c.Sort != sort || c.Desc != page.Desc:
+		return nil, failure.Invalid("cursor", "Cursor was issued for different sort order.")
+	}
+	return &Position{Value: c.Value, Seq: c.Seq}, nil
+}
+
+// validatePage sets default limit of page and reports whether page and
+// time range are valid.
+func validatePage(page *Page, from, to time.Time) error {
+	if page.Limit == 0 {
+		page.Limit = DefaultLimit
+	}
+
+	switch {
+	case page.Limit < 0 || page.Limit > MaxLimit:
+		return failure.Invalid("limit", "Limit must be in range [1, %d].", MaxLimit)
+	case !from.IsZero() && !to.IsZero() && to.Before(from):
+		return failure.Invalid("to", "Time range end must not be before its start.")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package listing
+
+import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/responding"
+)
+
+// Supported response outcomes.
+const (
+	OutcomeOK     = "ok"     // content fetched, assertions passed
+	OutcomeFailed = "failed" // content fetched, some assertion failed
+	OutcomeError  = "error"  // content not fetched, Err describes why
+)
+
+// Response is a single fetch response read from response history.
+// Text body is kept in Content as UTF-8, binary body is kept in Data.
+// Seq orders responses of all fetches by creation.
+type Response struct {
+	Seq        int
+	Content    string
+	Data       []byte
+	MediaType  string
+	Duration   float64
+	Delay      float64 // seconds spent waiting for host limiter
+	CreatedAt  float64
+	Outcome    string
+	Err        string
+	Manual     bool // fetched on request, not by schedule
+	Assertions []responding.CheckResult
+}
+
+// ResponseOf returns history record of response r stored at given time.
+// Seq of the record is not known.
+func ResponseOf(r responding.Response, at time.Time) Response {
+	return Response{
+		Content:    r.Content,
+		Data:       r.Data,
+		MediaType:  r.MediaType,
+		Duration:   r.Duration,
+		Delay:      r.Delay,
+		CreatedAt:  float64(at.UnixNano()) / float64(time.Second),
+		Outcome:    Outcome(r),
+		Err:        r.Err,
+		Manual:     r.Manual,
+		Assertions: r.Assertions,
+	}
+}
+
+// Outcome returns outcome of fetch which produced response r.
+func Outcome(r responding.Response) string {
+	switch {
+	case r.Err != "":
+		return OutcomeError
+	case r.Failed():
+		return OutcomeFailed
+	}
+	return OutcomeOK
+}
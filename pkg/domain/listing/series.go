@@ -0,0 +1,114 @@
+package listing
+
+import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+)
+
+// Supported time series aggregations.
+const (
+	AggAvg   = "avg"
+	AggMin   = "min"
+	AggMax   = "max"
+	AggSum   = "sum"
+	AggCount = "count"
+	AggFirst = "first"
+	AggLast  = "last"
+)
+
+// Point is a single value of time series. Value holds float64,
+// string or bool depending on series type.
+type Point struct {
+	At    time.Time   `json:"t"`
+	Value interface{} `json:"value"`
+}
+
+// Series represents values extracted from fetch responses
+// under the same extractor name.
+type Series struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Points []Point `json:"points"`
+}
+
+// SeriesQuery defines time range, bucket size and aggregation
+// used for reading time series.
+type SeriesQuery struct {
+	From        time.Time
+	To          time.Time
+	Step        time.Duration
+	Aggregation string
+}
+
+// typed returns points holding value of series type, so that points
+// extracted before the extractor type was changed are not aggregated.
+func typed(points []Point, typ string) []Point {
+	result := points[:0:0]
+	for _, p := range points {
+		var ok bool
+		switch typ {
+		case adding.ValueString:
+			_, ok = p.Value.(string)
+		case adding.ValueBool:
+			_, ok = p.Value.(bool)
+		default:
+			_, ok = p.Value.(float64)
+		}
+		if ok {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// aggregate groups points into buckets of step size aligned to
+// the Unix epoch and reduces each of them with agg function.
+// Points must be sorted by time.
+func aggregate(points []Point, step time.Duration, agg string) []Point {
+	result := []Point{}
+	for start := 0; start < len(points); {
+		bucket := points[start].At.Truncate(step)
+		end := start
+		for end < len(points) && points[end].At.Truncate(step).Equal(bucket) {
+			end++
+		}
+		result = append(result, Point{At: bucket, Value: reduce(points[start:end], agg)})
+		start = end
+	}
+	return result
+}
+
+// reduce returns single value for non empty bucket of points.
+func reduce(points []Point, agg string) interface{} {
+	switch agg {
+	case AggCount:
+		return float64(len(points))
+	case AggFirst:
+		return points[0].Value
+	case AggLast:
+		return points[len(points)-1].Value
+	}
+
+	// Numeric aggregations
+	acc := points[0].Value.(float64)
+	for _, p := range points[1:] {
+		v := p.Value.(float64)
+		switch agg {
+		case AggMin:
+			if v < acc {
+				acc = v
+			}
+		case AggMax:
+			if v > acc {
+				acc = v
+			}
+		default:
+			acc += v
+		}
+	}
+	if agg == AggAvg {
+		acc /= float64(len(points))
+	}
+	return acc
+}
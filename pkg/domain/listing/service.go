@@ -0,0 +1,187 @@
+package listing
+
+import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/ulid"
+)
+
+// RepositoryLister provides reading functionality from response repository.
+// History returns at most q.Limit responses matching q, sorted by q.Sort
+// and starting after q.After.
+type RepositoryLister interface {
+	History(tenant, id string, q HistoryQuery) ([]Response, bool)
+	Series(tenant, id, name string, from, to time.Time) (Series, bool)
+}
+
+// RepositoryFetches provides reading functionality from fetch repository.
+// Fetches returns at most q.Limit fetches matching q, sorted by creation
+// and starting after q.After. FindRecord returns ID of fetch with given
+// name.
+type RepositoryFetches interface {
+	Fetch(tenant, id string) (Fetch, bool)
+	Fetches(tenant string, q FetchQuery) []Fetch
+	FindRecord(tenant, name string) (string, bool)
+}
+
+// Service defines RepositoryLister and RepositoryFetches operation.
+type Service struct {
+	respRep  RepositoryLister
+	fetchRep RepositoryFetches
+}
+
+// History returns page of response history of tenant fetch with given
+// ID and cursor of the next page, empty on the last page. History of
+// fetch which has not run yet is empty. Fetches of other tenants are
+// reported as not found.
+func (s *Service) History(tenant, id string, q HistoryQuery) ([]Response, string, error) {
+	if id == "" {
+		return nil, "", failure.Invalid("id", "ID must not be empty.")
+	}
+	if err := validatePage(&q.Page, q.From, q.To); err != nil {
+		return nil, "", err
+	}
+
+	switch q.Sort {
+	case "":
+		q.Sort = SortCreated
+	case SortCreated, SortDuration:
+	default:
+		return nil, "", failure.Invalid("sort", "Sort %q is not supported.", q.Sort)
+	}
+
+	switch q.Outcome {
+	case "", OutcomeOK, OutcomeFailed, OutcomeError:
+	default:
+		return nil, "", failure.Invalid("outcome", "Outcome %q is not supported.", q.Outcome)
+	}
+
+	after, err := decodeCursor(q.Page, q.Sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit := q.Limit
+	q.After = after
+	q.Limit++ // one more tells whether next page exists
+	history, ok := s.respRep.History(tenant, id, q)
+	if !ok {
+		if _, ok := s.fetchRep.Fetch(tenant, id); ok { // not fetched yet
+			return []Response{}, "", nil
+		}
+		return nil, "", failure.Missing("History of fetch %q not found.", id)
+	}
+	if len(history) <= limit {
+		return history, "", nil
+	}
+
+	history = history[:limit]
+	last := history[limit-1]
+	pos := Position{Value: last.CreatedAt, Seq: last.Seq}
+	if q.Sort == SortDuration {
+		pos.Value = last.Duration
+	}
+	return history, encodeCursor(q.Sort, q.Desc, pos), nil
+}
+
+// Fetch returns tenant fetch with given ID. Fetches of other tenants
+// are reported as not found.
+func (s *Service) Fetch(tenant, id string) (Fetch, error) {
+	if id == "" {
+		return Fetch{}, failure.Invalid("id", "ID must not be empty.")
+	}
+
+	fetch, ok := s.fetchRep.Fetch(tenant, id)
+	if !ok {
+		return Fetch{}, failure.Missing("Fetch %q not found.", id)
+	}
+	return fetch, nil
+}
+
+// Resolve returns ID of tenant fetch referenced either by its ID or by
+// its name. Names never have form of ID, so ID is returned as it is,
+// even of deleted fetch whose history is not compacted yet. Names of
+// other tenants fetches are reported as not found.
+func (s *Service) Resolve(tenant, ref string) (string, error) {
+	if ref == "" {
+		return "", failure.Invalid("id", "ID must not be empty.")
+	}
+	if ulid.Valid(ref) {
+		return ref, nil
+	}
+	if id, ok := s.fetchRep.FindRecord(tenant, ref); ok {
+		return id, nil
+	}
+	return "", failure.Missing("Fetch %q not found.", ref)
+}
+
+// Fetches returns page of tenant fetches and cursor of the next page,
+// empty on the last page.
+func (s *Service) Fetches(tenant string, q FetchQuery) ([]Fetch, string, error) {
+	if err := validatePage(&q.Page, q.From, q.To); err != nil {
+		return nil, "", err
+	}
+
+	after, err := decodeCursor(q.Page, SortCreated)
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit := q.Limit
+	q.After = after
+	q.Limit++ // one more tells whether next page exists
+	fetches := s.fetchRep.Fetches(tenant, q)
+	if len(fetches) <= limit {
+		return fetches, "", nil
+	}
+
+	fetches = fetches[:limit]
+	last := fetches[limit-1]
+	pos := Position{Value: float64(last.CreatedAt.UnixNano()) / float64(time.Second), Seq: last.Seq}
+	return fetches, encodeCursor(SortCreated, q.Desc, pos), nil
+}
+
+// Series returns named time series of tenant fetch with given ID. Points
+// of other than series type are left out, the rest is aggregated into
+// q.Step buckets if step is greater than 0.
+func (s *Service) Series(tenant, id, name string, q SeriesQuery) (Series, error) {
+	switch {
+	case id == "":
+		return Series{}, failure.Invalid("id", "ID must not be empty.")
+	case q.To.Before(q.From):
+		return Series{}, failure.Invalid("to", "Time range end must not be before its start.")
+	case q.Step < 0:
+		return Series{}, failure.Invalid("step", "Step must be greater or equal 0.")
+	}
+
+	switch q.Aggregation {
+	case AggAvg, AggMin, AggMax, AggSum, AggCount, AggFirst, AggLast:
+	default:
+		return Series{}, failure.Invalid("agg", "Aggregation %q is not supported.", q.Aggregation)
+	}
+
+	series, ok := s.respRep.Series(tenant, id, name, q.From, q.To)
+	if !ok {
+		return Series{}, failure.Missing("Series %q not found.", name)
+	}
+
+	series.Points = typed(series.Points, series.Type)
+	if q.Step == 0 {
+		return series, nil
+	}
+
+	anyType := q.Aggregation == AggCount || q.Aggregation == AggFirst || q.Aggregation == AggLast
+	if series.Type != adding.ValueNumber && !anyType {
+		return Series{}, failure.Invalid("agg", "Aggregation %q requires number series.", q.Aggregation)
+	}
+
+	series.Points = aggregate(series.Points, q.Step, q.Aggregation)
+	return series, nil
+}
+
+// NewService creates a listing service with the necessary dependencies.
+func NewService(r RepositoryLister, f RepositoryFetches) Service {
+	return Service{r, f}
+}
@@ -0,0 +1,259 @@
+package listing_test
+
+import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+	. "github.com/gobuzz/pkg/domain/listing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("The listing service", func() {
+
+	Describe("When calling Series", func() {
+		var (
+			lister  Service
+			fakeRep FakeRepositoryLister
+			start   time.Time
+			query   SeriesQuery
+		)
+
+		BeforeEach(func() { // Configuration
+			start = time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+			fakeRep = FakeRepositoryLister{Points: []Point{
+				{At: start, Value: 1.0},
+				{At: start.Add(20 * time.Second), Value: 5.0},
+				{At: start.Add(40 * time.Second), Value: 3.0},
+				{At: start.Add(60 * time.Second), Value: 10.0},
+				{At: start.Add(90 * time.Second), Value: 20.0},
+			}}
+			query = SeriesQuery{From: start, To: start.Add(time.Hour), Aggregation: AggAvg}
+		})
+
+		JustBeforeEach(func() {
+			lister = NewService(&fakeRep, new(FakeRepositoryFetches)) // Creation
+		})
+
+		Context("When step is not set.", func() {
+			It("Should return raw points within time range.", func() {
+				query.To = start.Add(time.Minute)
+				series, err := lister.Series(adding.DefaultTenant, adding.FakeID, "value", query)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(series.Points).To(HaveLen(4))
+			})
+		})
+
+		Context("When step is set.", func() {
+			It("Should return points aggregated into step buckets.", func() {
+				data := map[string][]interface{}{
+					AggAvg:   {3.0, 15.0},
+					AggMin:   {1.0, 10.0},
+					AggMax:   {5.0, 20.0},
+					AggSum:   {9.0, 30.0},
+					AggCount: {3.0, 2.0},
+					AggFirst: {1.0, 10.0},
+					AggLast:  {3.0, 20.0},
+				}
+				for agg, values := range data {
+					query.Step = time.Minute
+					query.Aggregation = agg
+					series, err := lister.Series(adding.DefaultTenant, adding.FakeID, "value", query)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(series.Points).To(Equal([]Point{
+						{At: start, Value: values[0]},
+						{At: start.Add(time.Minute), Value: values[1]},
+					}))
+				}
+			})
+		})
+
+		Context("When series has points of other type.", func() {
+			BeforeEach(func() {
+				fakeRep.Points[1].Value = "5"
+			})
+
+			It("Should return raw points of series type only.", func() {
+				series, err := lister.Series(adding.DefaultTenant, adding.FakeID, "value", query)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(series.Points).To(HaveLen(4))
+				for _, p := range series.Points {
+					Expect(p.Value).To(BeAssignableToTypeOf(0.0))
+				}
+			})
+
+			It("Should aggregate points of series type only.", func() {
+				query.Step = time.Minute
+				series, err := lister.Series(adding.DefaultTenant, adding.FakeID, "value", query)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(series.Points).To(Equal([]Point{
+					{At: start, Value: 2.0},
+					{At: start.Add(time.Minute), Value: 15.0},
+				}))
+			})
+		})
+
+		Context("When query is not valid.", func() {
+			It("Should return validation or not found error.", func() {
+				_, err := lister.Series(adding.DefaultTenant, adding.FakeID, "missing", query)
+				Expect(err).To(Equal(&failure.NotFound{Msg: "Series \"missing\" not found."}))
+
+				query.Aggregation = "median"
+				_, err = lister.Series(adding.DefaultTenant, adding.FakeID, "value", query)
+				Expect(err).To(Equal(&failure.Validation{Field: "agg", Msg: "Aggregation \"median\" is not supported."}))
+
+				query.Aggregation = AggAvg
+				query.To = start.Add(-time.Minute)
+				_, err = lister.Series(adding.DefaultTenant, adding.FakeID, "value", query)
+				Expect(err).To(Equal(&failure.Validation{Field: "to", Msg: "Time range end must not be before its start."}))
+			})
+		})
+	})
+
+	Describe("When calling History", func() {
+		var (
+			lister  Service
+			fakeRep FakeRepositoryLister
+		)
+
+		BeforeEach(func() { // Configuration
+			fakeRep = FakeRepositoryLister{Responses: []Response{
+				{Seq: 1, Content: "abc", Duration: 0.5, Outcome: OutcomeOK},
+				{Seq: 2, Content: "null", Outcome: OutcomeError, Err: "Not Found"},
+				{Seq: 3, Content: "abd", Duration: 0.7, Outcome: OutcomeFailed},
+			}}
+		})
+
+		JustBeforeEach(func() {
+			lister = NewService(&fakeRep, new(FakeRepositoryFetches)) // Creation
+		})
+
+		It("Should return history of existing fetch.", func() {
+			history, next, err := lister.History(adding.DefaultTenant, adding.FakeID, HistoryQuery{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(Equal(fakeRep.Responses))
+			Expect(next).To(BeEmpty())
+		})
+
+		It("Should return pages linked with cursor.", func() {
+			history, next, err := lister.History(adding.DefaultTenant, adding.FakeID, HistoryQuery{Page: Page{Limit: 2}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(Equal(fakeRep.Responses[:2]))
+			Expect(next).NotTo(BeEmpty())
+
+			history, next, err = lister.History(adding.DefaultTenant, adding.FakeID, HistoryQuery{Page: Page{Limit: 2, Cursor: next}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(Equal(fakeRep.Responses[2:]))
+			Expect(next).To(BeEmpty())
+		})
+
+		It("Should return filtered history.", func() {
+			history, _, err := lister.History(adding.DefaultTenant, adding.FakeID, HistoryQuery{Outcome: OutcomeError})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(Equal(fakeRep.Responses[1:2]))
+		})
+
+		It("Should return validation error for invalid query.", func() {
+			data := map[string]HistoryQuery{
+				"limit":   {Page: Page{Limit: MaxLimit + 1}},
+				"cursor":  {Page: Page{Cursor: "woops"}},
+				"sort":    {Sort: "size"},
+				"outcome": {Outcome: "woops"},
+				"to":      {From: time.Now(), To: time.Now().Add(-time.Minute)},
+			}
+			for field, query := range data {
+				_, _, err := lister.History(adding.DefaultTenant, adding.FakeID, query)
+				Expect(err).To(BeAssignableToTypeOf(&failure.Validation{}))
+				Expect(err.(*failure.Validation).Field).To(Equal(field))
+			}
+
+			_, next, _ := lister.History(adding.DefaultTenant, adding.FakeID, HistoryQuery{Page: Page{Limit: 1}})
+			_, _, err := lister.History(adding.DefaultTenant, adding.FakeID, HistoryQuery{Page: Page{Limit: 1, Cursor: next}, Sort: SortDuration})
+			Expect(err).To(Equal(&failure.Validation{Field: "cursor", Msg: "Cursor was issued for different sort order."}))
+		})
+
+		It("Should return empty history of fetch which has not run yet.", func() {
+			const created = "01HZX3V6Q8J5K2M9N4P7R1S3T6"
+			fetches := &FakeRepositoryFetches{Records: []Fetch{{ID: created, URL: "https://httpbin.org/range/15", Interval: 60}}}
+			lister = NewService(&fakeRep, fetches)
+
+			history, next, err := lister.History(adding.DefaultTenant, created, HistoryQuery{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(BeEmpty())
+			Expect(next).To(BeEmpty())
+
+			_, _, err = lister.History("team-a", created, HistoryQuery{})
+			Expect(err).To(BeAssignableToTypeOf(&failure.NotFound{}))
+		})
+
+		It("Should return not found error for unknown fetch.", func() {
+			_, _, err := lister.History(adding.DefaultTenant, "7", HistoryQuery{})
+			Expect(err).To(Equal(&failure.NotFound{Msg: `History of fetch "7" not found.`}))
+		})
+
+		It("Should return not found error for fetch of other tenant.", func() {
+			_, _, err := lister.History("team-a", adding.FakeID, HistoryQuery{})
+			Expect(err).To(BeAssignableToTypeOf(&failure.NotFound{}))
+		})
+	})
+
+	Describe("When calling Fetches", func() {
+		var (
+			lister   Service
+			fetchRep FakeRepositoryFetches
+		)
+
+		BeforeEach(func() { // Configuration
+			created := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+			fetchRep = FakeRepositoryFetches{Records: []Fetch{
+				{ID: "01E74FS400A0000000000000AA", Seq: 0, URL: "https://httpbin.org/range/15", Interval: 10, Tags: []string{"prod"}, CreatedAt: created},
+				{ID: "01E74FS400A0000000000000AB", Seq: 1, Name: "delay", URL: "https://httpbin.org/delay/1", Interval: 10, CreatedAt: created},
+				{ID: "01E74FS400A0000000000000AC", Seq: 2, URL: "https://httpbin.org/range/20", Interval: 10, Tags: []string{"prod"}, CreatedAt: created},
+			}}
+		})
+
+		JustBeforeEach(func() {
+			lister = NewService(new(FakeRepositoryLister), &fetchRep) // Creation
+		})
+
+		It("Should return a single fetch of the tenant.", func() {
+			fetch, err := lister.Fetch(adding.DefaultTenant, "01E74FS400A0000000000000AB")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetch).To(Equal(fetchRep.Records[1]))
+
+			_, err = lister.Fetch("other", "01E74FS400A0000000000000AB")
+			Expect(err).To(Equal(&failure.NotFound{Msg: `Fetch "01E74FS400A0000000000000AB" not found.`}))
+		})
+
+		It("Should resolve fetch by its ID or name.", func() {
+			Expect(lister.Resolve(adding.DefaultTenant, "01E74FS400A0000000000000AA")).To(Equal("01E74FS400A0000000000000AA"))
+			Expect(lister.Resolve(adding.DefaultTenant, "delay")).To(Equal("01E74FS400A0000000000000AB"))
+
+			_, err := lister.Resolve("other", "delay")
+			Expect(err).To(Equal(&failure.NotFound{Msg: `Fetch "delay" not found.`}))
+		})
+
+		It("Should return pages of filtered fetches.", func() {
+			fetches, next, err := lister.Fetches(adding.DefaultTenant, FetchQuery{Page: Page{Limit: 1}, Tag: "prod"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetches).To(Equal(fetchRep.Records[:1]))
+
+			fetches, next, err = lister.Fetches(adding.DefaultTenant, FetchQuery{Page: Page{Limit: 1, Cursor: next}, Tag: "prod"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetches).To(Equal(fetchRep.Records[2:]))
+			Expect(next).To(BeEmpty())
+
+			fetches, _, err = lister.Fetches(adding.DefaultTenant, FetchQuery{URL: "delay"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetches).To(Equal(fetchRep.Records[1:2]))
+		})
+
+		It("Should return empty list for other tenant.", func() {
+			fetches, next, err := lister.Fetches("team-a", FetchQuery{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetches).To(BeEmpty())
+			Expect(next).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,27 @@
+package reconciling
+
+import (
+	"github.com/gobuzz/pkg/domain/adding"
+)
+
+// Manifest declares fetches which should exist. Fetches are matched
+// with stored ones by tenant and name, so each of them must be named.
+// Empty tenant is the default one. With Prune set, stored fetches of
+// manifest tenants which are not declared are deleted, as are fetches
+// of tenants of the previously applied manifest.
+type Manifest struct {
+	Prune   bool
+	Fetches []adding.Fetch
+}
+
+// RepositoryManifest provides manifest, e.g. read from a file.
+type RepositoryManifest interface {
+	Manifest() (Manifest, error)
+}
+
+// Runner starts and stops background fetching of stored fetches.
+// Starting running fetch restarts it.
+type Runner interface {
+	Start(id string, fetch adding.Fetch)
+	Stop(tenant, id string)
+}
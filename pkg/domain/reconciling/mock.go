@@ -0,0 +1,63 @@
+package reconciling
+
+import (
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
+// FakeRepositoryManifest defines RepositoryManifest mock returning
+// the same manifest or error on each call.
+type FakeRepositoryManifest struct {
+	Declared Manifest
+	Err      error
+}
+
+// Manifest implements RepositoryManifest interface.
+func (f *FakeRepositoryManifest) Manifest() (Manifest, error) {
+	return f.Declared, f.Err
+}
+
+// FakeRepositoryFetches defines RepositoryFetches mock keeping stored
+// fetches by tenant in Seq order.
+type FakeRepositoryFetches struct {
+	Records map[string][]listing.Fetch
+}
+
+// Fetches implements RepositoryFetches interface.
+func (f *FakeRepositoryFetches) Fetches(tenant string, q listing.FetchQuery) []listing.Fetch {
+	fetches := []listing.Fetch{}
+	for _, r := range f.Records[tenant] {
+		if (q.After == nil || r.Seq > q.After.Seq) && len(fetches) < q.Limit {
+			fetches = append(fetches, r)
+		}
+	}
+	return fetches
+}
+
+// DeleteRecord implements RepositoryFetches interface.
+func (f *FakeRepositoryFetches) DeleteRecord(tenant, id string) bool {
+	for i, r := range f.Records[tenant] {
+		if r.ID == id {
+			f.Records[tenant] = append(f.Records[tenant][:i:i], f.Records[tenant][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// FakeRunner defines Runner mock recording started and stopped
+// fetch IDs.
+type FakeRunner struct {
+	Started []string
+	Stopped []string
+}
+
+// Start implements Runner interface.
+func (f *FakeRunner) Start(id string, fetch adding.Fetch) {
+	f.Started = append(f.Started, id)
+}
+
+// Stop implements Runner interface.
+func (f *FakeRunner) Stop(tenant, id string) {
+	f.Stopped = append(f.Stopped, id)
+}
@@ -0,0 +1,59 @@
+package reconciling
+
+import (
+	"reflect"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
+// Actions of plan changes, applied in this order.
+const (
+	ActionDelete = "delete"
+	ActionUpdate = "update"
+	ActionCreate = "create"
+)
+
+// Change is a single step bringing stored fetches to manifest.
+type Change struct {
+	Action string   `json:"action"`
+	Tenant string   `json:"tenant"`
+	Name   string   `json:"name,omitempty"`   // empty for deleted unnamed fetch
+	ID     string   `json:"id,omitempty"`     // empty until fetch is created
+	Fields []string `json:"fields,omitempty"` // changed by update
+	Err    string   `json:"error,omitempty"`  // set if applying failed
+
+	fetch adding.Fetch // declared definition
+}
+
+// Plan lists changes of stored fetches required by manifest.
+type Plan struct {
+	Prune     bool     `json:"prune"`
+	Changes   []Change `json:"changes"`
+	Unchanged int      `json:"unchanged"`
+}
+
+// diff returns names of fetch fields which differ between stored and
+// declared definition. Missing and empty lists are equal.
+func diff(stored listing.Fetch, declared adding.Fetch) []string {
+	var fields []string
+	if stored.URL != declared.URL {
+		fields = append(fields, "url")
+	}
+	if stored.Interval != declared.Interval {
+		fields = append(fields, "interval")
+	}
+	if len(stored.Assertions)+len(declared.Assertions) > 0 && !reflect.DeepEqual(stored.Assertions, declared.Assertions) {
+		fields = append(fields, "assertions")
+	}
+	if len(stored.Extractors)+len(declared.Extractors) > 0 && !reflect.DeepEqual(stored.Extractors, declared.Extractors) {
+		fields = append(fields, "extractors")
+	}
+	if !reflect.DeepEqual(stored.Retention, declared.Retention) {
+		fields = append(fields, "retention")
+	}
+	if len(stored.Tags)+len(declared.Tags) > 0 && !reflect.DeepEqual(stored.Tags, declared.Tags) {
+		fields = append(fields, "tags")
+	}
+	return fields
+}
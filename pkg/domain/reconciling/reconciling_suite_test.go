@@ -0,0 +1,13 @@
+package reconciling_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestReconciling(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reconciling Service Suite")
+}
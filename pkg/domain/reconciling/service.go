@@ -0,0 +1,226 @@
+package reconciling
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
+// RepositoryFetches provides stored fetches of a tenant and their
+// removal.
+type RepositoryFetches interface {
+	Fetches(tenant string, q listing.FetchQuery) []listing.Fetch
+	DeleteRecord(tenant, id string) bool // false if there is no such fetch
+}
+
+// Service defines reconciling of stored fetches with manifest.
+type Service struct {
+	manifestRep RepositoryManifest
+	fetchRep    RepositoryFetches
+	adder       adding.Service
+	runner      Runner
+	mu          *sync.Mutex     // serializes planning and applying plans
+	owned       map[string]bool // tenants of the last applied manifest
+}
+
+// order ranks actions of plan changes.
+var order = map[string]int{ActionDelete: 0, ActionUpdate: 1, ActionCreate: 2}
+
+// Plan returns changes which Apply would do without doing them.
+func (s *Service) Plan() (Plan, error) {
+	if s.mu == nil {
+		return Plan{}, failure.Missing("Manifest is not configured.")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, _, err := s.plan()
+	return p, err
+}
+
+// plan returns changes bringing stored fetches to manifest and tenants
+// the manifest declares fetches of. With prune, tenants of the last
+// applied manifest are pruned too, so that fetches of tenant dropped
+// from manifest are deleted. Must be called with s.mu held.
+func (s *Service) plan() (Plan, []string, error) {
+	if s.manifestRep == nil {
+		return Plan{}, nil, failure.Missing("Manifest is not configured.")
+	}
+
+	m, err := s.manifestRep.Manifest()
+	if err != nil {
+		return Plan{}, nil, err
+	}
+
+	// Declared fetches grouped by tenant in manifest order.
+	var tenants []string
+	declared := make(map[string][]adding.Fetch)
+	names := make(map[string]map[string]bool)
+	for i, f := range m.Fetches {
+		if f.Tenant == "" {
+			f.Tenant = adding.DefaultTenant
+		}
+		if err := s.validate(i, f); err != nil {
+			return Plan{}, nil, err
+		}
+		if names[f.Tenant][f.Name] {
+			return Plan{}, nil, failure.Invalid(fmt.Sprintf("fetches[%d].name", i), "Fetch %q is already declared.", f.Name)
+		}
+
+		if names[f.Tenant] == nil {
+			names[f.Tenant] = make(map[string]bool)
+			tenants = append(tenants, f.Tenant)
+		}
+		names[f.Tenant][f.Name] = true
+		declared[f.Tenant] = append(declared[f.Tenant], f)
+	}
+
+	pruned := tenants
+	if m.Prune {
+		var dropped []string
+		for tenant := range s.owned {
+			if names[tenant] == nil {
+				dropped = append(dropped, tenant)
+			}
+		}
+		sort.Strings(dropped)
+		pruned = append(pruned[:len(pruned):len(pruned)], dropped...)
+	}
+
+	p := Plan{Prune: m.Prune, Changes: []Change{}}
+	for _, tenant := range pruned {
+		stored := s.fetches(tenant)
+		byName := make(map[string]listing.Fetch)
+		for _, f := range stored {
+			if f.Name != "" {
+				byName[f.Name] = f
+			}
+		}
+
+		for _, f := range declared[tenant] {
+			found, ok := byName[f.Name]
+			if !ok {
+				p.Changes = append(p.Changes, Change{Action: ActionCreate, Tenant: tenant, Name: f.Name, fetch: f})
+				continue
+			}
+			if fields := diff(found, f); len(fields) > 0 {
+				p.Changes = append(p.Changes, Change{Action: ActionUpdate, Tenant: tenant, Name: f.Name, ID: found.ID, Fields: fields, fetch: f})
+				continue
+			}
+			p.Unchanged++
+		}
+
+		for _, f := range stored {
+			if m.Prune && !names[tenant][f.Name] {
+				p.Changes = append(p.Changes, Change{Action: ActionDelete, Tenant: tenant, Name: f.Name, ID: f.ID})
+			}
+		}
+	}
+
+	sort.SliceStable(p.Changes, func(i, j int) bool {
+		return order[p.Changes[i].Action] < order[p.Changes[j].Action]
+	})
+	return p, tenants, nil
+}
+
+// Apply brings stored fetches to manifest and returns applied plan.
+// Deleted fetches are stopped, created and updated ones are (re)started.
+// Failing change does not stop the others, it is reported in its Err
+// and with returned error. Tenants of applied manifest are remembered
+// for pruning by the next one, tenants of the previous one are kept
+// until all changes succeed.
+func (s *Service) Apply() (Plan, error) {
+	if s.mu == nil {
+		return Plan{}, failure.Missing("Manifest is not configured.")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, tenants, err := s.plan()
+	if err != nil {
+		return p, err
+	}
+
+	failed := 0
+	for i := range p.Changes {
+		c := &p.Changes[i]
+		var err error
+		switch c.Action {
+		case ActionDelete:
+			if !s.fetchRep.DeleteRecord(c.Tenant, c.ID) {
+				err = failure.Missing("Fetch %q not found.", c.ID)
+				break
+			}
+			s.runner.Stop(c.Tenant, c.ID)
+		case ActionUpdate:
+			if err = s.adder.UpdateRecord(c.Tenant, c.ID, c.fetch); err == nil {
+				s.runner.Start(c.ID, c.fetch)
+			}
+		case ActionCreate:
+			if c.ID, err = s.adder.CreateRecord(c.fetch); err == nil {
+				s.runner.Start(c.ID, c.fetch)
+			}
+		}
+		if err != nil {
+			c.Err = err.Error()
+			failed++
+		}
+	}
+
+	if failed == 0 {
+		for tenant := range s.owned {
+			delete(s.owned, tenant)
+		}
+	}
+	for _, tenant := range tenants {
+		s.owned[tenant] = true
+	}
+
+	if failed > 0 {
+		return p, fmt.Errorf("%d of %d manifest changes failed", failed, len(p.Changes))
+	}
+	return p, nil
+}
+
+// validate reports whether i-th declared fetch is valid. Field of
+// validation error points into manifest.
+func (s *Service) validate(i int, f adding.Fetch) error {
+	if f.Name == "" {
+		return failure.Invalid(fmt.Sprintf("fetches[%d].name", i), "Fetch %d: name is required.", i)
+	}
+
+	err := s.adder.Validate(f)
+	var validation *failure.Validation
+	if errors.As(err, &validation) {
+		return failure.Invalid(fmt.Sprintf("fetches[%d].%s", i, validation.Field), "Fetch %q: %s", f.Name, validation.Msg)
+	}
+	return err
+}
+
+// fetches returns all stored fetches of the tenant.
+func (s *Service) fetches(tenant string) []listing.Fetch {
+	var all []listing.Fetch
+	q := listing.FetchQuery{Page: listing.Page{Limit: listing.MaxLimit}}
+	for {
+		page := s.fetchRep.Fetches(tenant, q)
+		all = append(all, page...)
+		if len(page) < q.Limit {
+			return all
+		}
+		q.After = &listing.Position{Seq: page[len(page)-1].Seq}
+	}
+}
+
+// NewService creates a reconciling service with the necessary
+// dependencies. Created and updated fetches go through adder, so they
+// are validated and counted into quotas as any other.
+func NewService(m RepositoryManifest, f RepositoryFetches, a adding.Service, r Runner) Service {
+	return Service{manifestRep: m, fetchRep: f, adder: a, runner: r, mu: new(sync.Mutex), owned: make(map[string]bool)}
+}
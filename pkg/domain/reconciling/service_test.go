@@ -0,0 +1,183 @@
+package reconciling_test
+
+import (
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/domain/listing"
+	. "github.com/gobuzz/pkg/domain/reconciling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("The reconciling service", func() {
+	var (
+		reconciler  Service
+		manifestRep FakeRepositoryManifest
+		fetchRep    FakeRepositoryFetches
+		runner      FakeRunner
+		quotaRep    adding.FakeRepositoryQuotas
+	)
+
+	const front, slow, unnamed = "01HZX3V6Q8J5K2M9N4P7R1S3A0", "01HZX3V6Q8J5K2M9N4P7R1S3A1", "01HZX3V6Q8J5K2M9N4P7R1S3A2"
+
+	BeforeEach(func() { // Configuration
+		fetchRep = FakeRepositoryFetches{Records: map[string][]listing.Fetch{
+			adding.DefaultTenant: {
+				{ID: front, Seq: 0, Name: "front", URL: "https://httpbin.org/range/15", Interval: 60},
+				{ID: slow, Seq: 1, Name: "slow", URL: "https://httpbin.org/delay/3", Interval: 60, Tags: []string{"prod"}},
+				{ID: unnamed, Seq: 2, URL: "https://httpbin.org/delay/1", Interval: 60},
+			},
+		}}
+		manifestRep = FakeRepositoryManifest{Declared: Manifest{Fetches: []adding.Fetch{
+			{Name: "front", URL: "https://httpbin.org/range/15", Interval: 30, Tags: []string{"prod"}},
+			{Name: "slow", URL: "https://httpbin.org/delay/3", Interval: 60, Tags: []string{"prod"}},
+			{Name: "new", URL: "https://httpbin.org/range/20", Interval: 60},
+		}}}
+		runner = FakeRunner{}
+		quotaRep = adding.FakeRepositoryQuotas{}
+	})
+
+	JustBeforeEach(func() {
+		adder := adding.NewService(new(adding.FakeRepositoryAdder), &quotaRep)
+		reconciler = NewService(&manifestRep, &fetchRep, adder, &runner) // Creation
+	})
+
+	Describe("When calling Plan", func() {
+		Context("When manifest differs from stored fetches.", func() {
+			It("Should report creating and updating fetches only.", func() {
+				plan, err := reconciler.Plan()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.Unchanged).To(Equal(1))
+				Expect(plan.Changes).To(HaveLen(2))
+				Expect(plan.Changes[0]).To(matchChange(ActionUpdate, "front", front, []string{"interval", "tags"}))
+				Expect(plan.Changes[1]).To(matchChange(ActionCreate, "new", "", nil))
+				Expect(runner.Started).To(BeEmpty())
+			})
+		})
+
+		Context("When manifest prunes fetches.", func() {
+			BeforeEach(func() {
+				manifestRep.Declared.Prune = true
+			})
+
+			It("Should report deleting undeclared fetches first.", func() {
+				plan, err := reconciler.Plan()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.Changes).To(HaveLen(3))
+				Expect(plan.Changes[0]).To(matchChange(ActionDelete, "", unnamed, nil))
+			})
+		})
+
+		Context("When manifest is not valid.", func() {
+			It("Should point at the invalid fetch.", func() {
+				manifestRep.Declared.Fetches[1].Name = ""
+				_, err := reconciler.Plan()
+				Expect(err).To(Equal(&failure.Validation{Field: "fetches[1].name", Msg: "Fetch 1: name is required."}))
+
+				manifestRep.Declared.Fetches[1].Name = "front"
+				_, err = reconciler.Plan()
+				Expect(err).To(Equal(&failure.Validation{Field: "fetches[1].name", Msg: `Fetch "front" is already declared.`}))
+
+				manifestRep.Declared.Fetches[1] = adding.Fetch{Name: "slow", URL: "Woops!", Interval: 60}
+				_, err = reconciler.Plan()
+				Expect(err).To(Equal(&failure.Validation{Field: "fetches[1].url", Msg: `Fetch "slow": URL path is not accepted.`}))
+			})
+		})
+
+		Context("When manifest is not configured.", func() {
+			It("Should report it as not found.", func() {
+				_, err := new(Service).Plan()
+				Expect(err).To(Equal(&failure.NotFound{Msg: "Manifest is not configured."}))
+			})
+		})
+	})
+
+	Describe("When calling Apply", func() {
+		BeforeEach(func() {
+			manifestRep.Declared.Prune = true
+		})
+
+		It("Should delete, update and create fetches and their Gophers.", func() {
+			plan, err := reconciler.Apply()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Changes).To(HaveLen(3))
+			Expect(plan.Changes[2].ID).To(Equal(adding.FakeID)) // created by fake repository
+			Expect(runner.Stopped).To(Equal([]string{unnamed}))
+			Expect(runner.Started).To(Equal([]string{front, adding.FakeID}))
+			Expect(fetchRep.Records[adding.DefaultTenant]).To(HaveLen(2))
+		})
+
+		Context("When change fails.", func() {
+			BeforeEach(func() {
+				quotaRep.Default = adding.Quota{MinInterval: 45}
+			})
+
+			It("Should apply the other changes and report the failed one.", func() {
+				plan, err := reconciler.Apply()
+				Expect(err).To(MatchError("1 of 3 manifest changes failed"))
+				Expect(plan.Changes[1].Err).To(Equal("Interval value must be greater or equal 45."))
+				Expect(runner.Stopped).To(Equal([]string{unnamed}))
+				Expect(runner.Started).To(Equal([]string{adding.FakeID}))
+			})
+		})
+
+		Context("When tenant is dropped from manifest.", func() {
+			const team = "01HZX3V6Q8J5K2M9N4P7R1S3B0"
+
+			BeforeEach(func() {
+				fetchRep.Records["team-a"] = []listing.Fetch{{ID: team, Name: "a", URL: "https://httpbin.org/range/15", Interval: 60}}
+				manifestRep.Declared.Fetches = append(manifestRep.Declared.Fetches, adding.Fetch{Tenant: "team-a", Name: "a", URL: "https://httpbin.org/range/15", Interval: 60})
+			})
+
+			It("Should delete the last fetch of the tenant.", func() {
+				_, err := reconciler.Apply()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(runner.Stopped).To(Equal([]string{unnamed}))
+
+				manifestRep.Declared.Fetches = manifestRep.Declared.Fetches[:3]
+				plan, err := reconciler.Plan()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.Changes[0]).To(matchChange(ActionDelete, "a", team, nil))
+				Expect(plan.Changes[0].Tenant).To(Equal("team-a"))
+
+				_, err = reconciler.Apply()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(runner.Stopped).To(Equal([]string{unnamed, team}))
+				Expect(fetchRep.Records["team-a"]).To(BeEmpty())
+			})
+
+			It("Should delete fetches of all tenants with empty manifest.", func() {
+				_, err := reconciler.Apply()
+				Expect(err).NotTo(HaveOccurred())
+
+				manifestRep.Declared.Fetches = nil
+				plan, err := reconciler.Apply()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.Changes).To(HaveLen(3))
+				Expect(fetchRep.Records[adding.DefaultTenant]).To(BeEmpty())
+				Expect(fetchRep.Records["team-a"]).To(BeEmpty())
+			})
+		})
+
+		Context("When manifest is not valid.", func() {
+			BeforeEach(func() {
+				manifestRep.Declared.Fetches = append(manifestRep.Declared.Fetches, adding.Fetch{Tenant: "Other", Name: "x"})
+			})
+
+			It("Should not apply any change.", func() {
+				_, err := reconciler.Apply()
+				Expect(err).To(HaveOccurred())
+				Expect(runner.Started).To(BeEmpty())
+				Expect(runner.Stopped).To(BeEmpty())
+			})
+		})
+	})
+})
+
+// matchChange matches plan change by its action, name, ID and changed
+// fields.
+func matchChange(action, name, id string, fields []string) OmegaMatcher {
+	return WithTransform(func(c Change) []interface{} {
+		return []interface{}{c.Action, c.Name, c.ID, c.Fields}
+	}, Equal([]interface{}{action, name, id, fields}))
+}
@@ -0,0 +1,42 @@
+package replaying
+
+import "time"
+
+// FakeRepositoryRecords defines RepositoryRecords mock keeping records
+// in map.
+type FakeRepositoryRecords struct {
+	records map[[2]string]Record
+}
+
+// ReserveRecord implements RepositoryRecords interface.
+func (f *FakeRepositoryRecords) ReserveRecord(r Record, since time.Time) (Record, bool) {
+	if f.records == nil {
+		f.records = make(map[[2]string]Record)
+	}
+	k := [2]string{r.Tenant, r.Key}
+	if old, ok := f.records[k]; ok && !old.CreatedAt.Before(since) {
+		return old, false
+	}
+	f.records[k] = r
+	return r, true
+}
+
+// CompleteRecord implements RepositoryRecords interface.
+func (f *FakeRepositoryRecords) CompleteRecord(tenant, key, id string, found bool) bool {
+	k := [2]string{tenant, key}
+	r, ok := f.records[k]
+	if !ok {
+		return false
+	}
+	r.ID, r.Found = id, found
+	f.records[k] = r
+	return true
+}
+
+// DeleteRecord implements RepositoryRecords interface.
+func (f *FakeRepositoryRecords) DeleteRecord(tenant, key string) bool {
+	k := [2]string{tenant, key}
+	_, ok := f.records[k]
+	delete(f.records, k)
+	return ok
+}
@@ -0,0 +1,15 @@
+package replaying
+
+import "time"
+
+// Record is outcome of request sent with idempotency key. Record without
+// ID is of request which is still in progress. Found is set if request
+// in dedup mode returned existing fetch instead of creating one.
+type Record struct {
+	Tenant    string
+	Key       string
+	Hash      [32]byte // of request payload
+	ID        string   // of created fetch
+	Found     bool
+	CreatedAt time.Time
+}
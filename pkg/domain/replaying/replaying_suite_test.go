@@ -0,0 +1,13 @@
+package replaying_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestReplaying(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Replaying Service Suite")
+}
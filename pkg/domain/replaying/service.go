@@ -0,0 +1,85 @@
+package replaying
+
+import (
+	"crypto/sha256"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/failure"
+)
+
+// MaxKeyLength is the longest accepted idempotency key.
+const MaxKeyLength = 255
+
+// DefaultWindow is how long records are kept if no window is given.
+const DefaultWindow = 24 * time.Hour
+
+// RepositoryRecords provides storing functionality of idempotency
+// records repository.
+type RepositoryRecords interface {
+	ReserveRecord(r Record, since time.Time) (Record, bool)
+	CompleteRecord(tenant, key, id string, found bool) bool
+	DeleteRecord(tenant, key string) bool
+}
+
+// Service defines RepositoryRecords operation. Records are kept for
+// window since the request which reserved the key.
+type Service struct {
+	recordRep RepositoryRecords
+	window    time.Duration
+}
+
+// Begin reserves idempotency key of tenant for request with given
+// payload. Replayed reports that the key was used for the same payload
+// within window, returned record holds ID of fetch created then. Key
+// used for other payload fails with failure.Mismatch, key of request
+// still in progress with failure.Conflict. Service without repository
+// keeps nothing, so each request is a new one.
+func (s *Service) Begin(tenant, key string, payload []byte) (rec Record, replayed bool, err error) {
+	switch {
+	case key == "":
+		return Record{}, false, failure.Invalid("Idempotency-Key", "Idempotency key must not be empty.")
+	case len(key) > MaxKeyLength:
+		return Record{}, false, failure.Invalid("Idempotency-Key", "Idempotency key must be at most %d characters long.", MaxKeyLength)
+	}
+	if s.recordRep == nil {
+		return Record{}, false, nil
+	}
+
+	now := time.Now()
+	rec = Record{Tenant: tenant, Key: key, Hash: sha256.Sum256(payload), CreatedAt: now}
+	stored, reserved := s.recordRep.ReserveRecord(rec, now.Add(-s.window))
+	switch {
+	case reserved:
+		return rec, false, nil
+	case stored.Hash != rec.Hash:
+		return Record{}, false, &failure.Mismatch{Msg: "Idempotency key was used for request with other payload."}
+	case stored.ID == "":
+		return Record{}, false, &failure.Conflict{Msg: "Request with the same idempotency key is in progress."}
+	}
+	return stored, true, nil
+}
+
+// Complete stores ID of fetch created, or found if found is set, by
+// request which reserved the key, so that its replays return it.
+func (s *Service) Complete(tenant, key, id string, found bool) {
+	if s.recordRep != nil {
+		s.recordRep.CompleteRecord(tenant, key, id, found)
+	}
+}
+
+// Release frees the key reserved by request which failed, so that it can
+// be retried.
+func (s *Service) Release(tenant, key string) {
+	if s.recordRep != nil {
+		s.recordRep.DeleteRecord(tenant, key)
+	}
+}
+
+// NewService creates a replaying service with the necessary dependencies.
+// Non-positive window means DefaultWindow.
+func NewService(r RepositoryRecords, window time.Duration) Service {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return Service{recordRep: r, window: window}
+}
@@ -0,0 +1,121 @@
+package replaying_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/failure"
+	. "github.com/gobuzz/pkg/domain/replaying"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("The replaying service", func() {
+	var (
+		replayer  Service
+		recordRep *FakeRepositoryRecords
+		window    time.Duration
+	)
+
+	const payload = `{"url":"https://httpbin.org/range/15","interval":60}`
+
+	BeforeEach(func() { // Configuration
+		recordRep = new(FakeRepositoryRecords)
+		window = time.Hour
+	})
+
+	JustBeforeEach(func() {
+		replayer = NewService(recordRep, window) // Creation
+	})
+
+	Describe("When calling Begin", func() {
+		It("Should reserve unused key.", func() {
+			_, replayed, err := replayer.Begin("default", "k1", []byte(payload))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replayed).To(BeFalse())
+		})
+
+		It("Should replay completed request with the same payload.", func() {
+			_, _, err := replayer.Begin("default", "k1", []byte(payload))
+			Expect(err).NotTo(HaveOccurred())
+			replayer.Complete("default", "k1", "01HZX3V6Q8J5K2M9N4P7R1S3T5", false)
+
+			rec, replayed, err := replayer.Begin("default", "k1", []byte(payload))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replayed).To(BeTrue())
+			Expect(rec.ID).To(Equal("01HZX3V6Q8J5K2M9N4P7R1S3T5"))
+		})
+
+		It("Should keep keys of tenants apart.", func() {
+			replayer.Begin("default", "k1", []byte(payload))
+			replayer.Complete("default", "k1", "01HZX3V6Q8J5K2M9N4P7R1S3T5", false)
+
+			_, replayed, err := replayer.Begin("team-a", "k1", []byte(payload))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replayed).To(BeFalse())
+		})
+
+		It("Should return mismatch error for the key used with other payload.", func() {
+			replayer.Begin("default", "k1", []byte(payload))
+			replayer.Complete("default", "k1", "01HZX3V6Q8J5K2M9N4P7R1S3T5", false)
+
+			_, _, err := replayer.Begin("default", "k1", []byte(`{"url":"https://httpbin.org/range/20","interval":60}`))
+			Expect(err).To(MatchError("Idempotency key was used for request with other payload."))
+			Expect(err).To(BeAssignableToTypeOf(&failure.Mismatch{}))
+		})
+
+		It("Should return conflict error while request with the key is in progress.", func() {
+			replayer.Begin("default", "k1", []byte(payload))
+
+			_, _, err := replayer.Begin("default", "k1", []byte(payload))
+			Expect(err).To(MatchError("Request with the same idempotency key is in progress."))
+			Expect(err).To(BeAssignableToTypeOf(&failure.Conflict{}))
+		})
+
+		It("Should reserve the key again once released.", func() {
+			replayer.Begin("default", "k1", []byte(payload))
+			replayer.Release("default", "k1")
+
+			_, replayed, err := replayer.Begin("default", "k1", []byte(`{"url":"https://httpbin.org/range/20","interval":60}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replayed).To(BeFalse())
+		})
+
+		It("Should return validation error for empty or too long key.", func() {
+			_, _, err := replayer.Begin("default", "", []byte(payload))
+			Expect(err).To(MatchError("Idempotency key must not be empty."))
+			Expect(err).To(BeAssignableToTypeOf(&failure.Validation{}))
+
+			_, _, err = replayer.Begin("default", strings.Repeat("k", MaxKeyLength+1), []byte(payload))
+			Expect(err).To(MatchError("Idempotency key must be at most 255 characters long."))
+			Expect(err).To(BeAssignableToTypeOf(&failure.Validation{}))
+		})
+
+		Context("With short window", func() {
+			BeforeEach(func() {
+				window = time.Millisecond
+			})
+
+			It("Should forget the key after window.", func() {
+				replayer.Begin("default", "k1", []byte(payload))
+				replayer.Complete("default", "k1", "01HZX3V6Q8J5K2M9N4P7R1S3T5", false)
+				time.Sleep(2 * window)
+
+				_, replayed, err := replayer.Begin("default", "k1", []byte(`{"url":"https://httpbin.org/range/20","interval":60}`))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(replayed).To(BeFalse())
+			})
+		})
+
+		Context("Without repository", func() {
+			It("Should treat each request as a new one.", func() {
+				replayer = NewService(nil, window)
+				replayer.Begin("default", "k1", []byte(payload))
+
+				_, replayed, err := replayer.Begin("default", "k1", []byte(payload))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(replayed).To(BeFalse())
+			})
+		})
+	})
+})
@@ -1,9 +1,48 @@
 package responding
 
 // Response stores data coming back from fetchURL routine
-// used in background by Gopher.
+// used in background by Gopher. Text body is kept in Content
+// as UTF-8, binary body is kept in Data. StorageKeyID is
+// ID of the fetch of Tenant. Err describes why fetch failed, Content is
+// "null" then. Manual response was fetched on request, not by schedule.
 type Response struct {
-	StorageKeyID int
+	StorageKeyID string
+	Tenant       string
 	Content      string
+	Data         []byte
+	MediaType    string
 	Duration     float64
+	Delay        float64 // seconds spent waiting for host limiter
+	Err          string
+	Manual       bool
+	Assertions   []CheckResult
+	Samples      []Sample
+}
+
+// CheckResult describes outcome of a single fetch assertion
+// evaluated by Gopher against fetched content.
+type CheckResult struct {
+	Type   string `json:"type"`
+	Passed bool   `json:"passed"`
+	Msg    string `json:"msg,omitempty"`
+}
+
+// Sample is a single typed value extracted by Gopher from fetched
+// response. Only field matching Type is set.
+type Sample struct {
+	Name   string
+	Type   string
+	Number float64
+	Text   string
+	Bool   bool
+}
+
+// Failed reports whether any of the response assertions did not pass.
+func (r *Response) Failed() bool {
+	for _, a := range r.Assertions {
+		if !a.Passed {
+			return true
+		}
+	}
+	return false
 }
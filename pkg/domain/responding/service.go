@@ -1,13 +1,10 @@
 package responding
 
-import (
-	"fmt"
-	"net/http"
-)
+import "github.com/gobuzz/pkg/domain/failure"
 
 // RepositoryAdder provides adding functionality to requests response repository.
 type RepositoryAdder interface {
-	CreateRecord(record Response) ServiceValidation
+	CreateRecord(record Response) (int, error)
 }
 
 // Service defines RepositoryAdder operation.
@@ -15,34 +12,26 @@ type Service struct {
 	reqsRep RepositoryAdder
 }
 
-// ServiceValidation represetns response body sending to client
-// when validation check fails.
-type ServiceValidation struct {
-	StorageKeyID int
-	Status       int
-	Msg          string
-}
-
 // CreateRecord provides adding request into Service repository.
-func (s *Service) CreateRecord(record Response) ServiceValidation {
+// Returns ID of the stored response.
+func (s *Service) CreateRecord(record Response) (int, error) {
 
 	//.. Validation logic
 	switch {
-	case record.StorageKeyID < 0:
-		txt := fmt.Sprintf("StorageKeyID must be greater or equal 0.\n")
-		return ServiceValidation{StorageKeyID: -1, Status: http.StatusBadRequest, Msg: txt}
+	case record.StorageKeyID == "":
+		return -1, failure.Invalid("id", "StorageKeyID must not be empty.")
+	case record.Content != "" && record.Data != nil:
+		return -1, failure.Invalid("content", "Response must have either text or binary body.")
+
 	case record.Duration > 5.0 && record.Content != "null":
 
-		txt := fmt.Sprintf("Response duration longer than 5s should return null as content.\n")
-		return ServiceValidation{StorageKeyID: -1, Status: http.StatusBadRequest, Msg: txt}
+		return -1, failure.Invalid("content", "Response duration longer than 5s should return null as content.")
 
-	case len(record.Content) <= 0 || len(record.Content) > 102402:
-		txt := fmt.Sprintf("Response string must be in range (0, 102402) characters.\n")
-		return ServiceValidation{StorageKeyID: -1, Status: http.StatusBadRequest, Msg: txt}
+	case len(record.Content)+len(record.Data) <= 0 || len(record.Content)+len(record.Data) > 102402:
+		return -1, failure.Invalid("content", "Response string must be in range (0, 102402) characters.")
 
 	case record.Duration > 5.0:
-		txt := fmt.Sprintf("Response duration cannot be longer than 5s.\n")
-		return ServiceValidation{StorageKeyID: -1, Status: http.StatusBadRequest, Msg: txt}
+		return -1, failure.Invalid("duration", "Response duration cannot be longer than 5s.")
 	}
 
 	return s.reqsRep.CreateRecord(record)
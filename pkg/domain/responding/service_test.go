@@ -1,9 +1,7 @@
 package responding_test
 
 import (
-	"fmt"
-	"net/http"
-
+	"github.com/gobuzz/pkg/domain/failure"
 	. "github.com/gobuzz/pkg/domain/responding"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -12,7 +10,8 @@ import (
 // testContent is an internal aggregate for creating tableTest slice.
 type testContent struct {
 	Response
-	ServiceValidation
+	ID  int
+	Err error
 }
 
 var _ = Describe("Responding Service", func() {
@@ -30,27 +29,30 @@ var _ = Describe("Responding Service", func() {
 		BeforeEach(func() { // Configuration
 			data = []testContent{
 				{
-					Response{0, "abcdefegh", 0.342},
-					ServiceValidation{0, http.StatusOK, fmt.Sprintf("Record has been insert into response db.\n")},
+					Response{StorageKeyID: "0", Content: "abcdefegh", Duration: 0.342},
+					0, nil,
+				},
+				{
+					Response{StorageKeyID: "1", Content: "abcdefghij", Duration: 0.560},
+					0, nil,
 				},
 				{
-					Response{1, "abcdefghij", 0.560},
-					ServiceValidation{0, http.StatusOK, fmt.Sprintf("Record has been insert into response db.\n")},
+					Response{StorageKeyID: "1", Content: "abcdefghij", Duration: 0.560},
+					0, nil,
 				},
 				{
-					Response{1, "abcdefghij", 0.560},
-					ServiceValidation{0, http.StatusOK, fmt.Sprintf("Record has been insert into response db.\n")},
+					Response{StorageKeyID: "2", Data: []byte{0x89, 0x50, 0x4e, 0x47}, MediaType: "image/png", Duration: 0.120},
+					0, nil,
 				},
 			}
 		})
 
 		Context("When response content is valid.", func() {
-			It("Should return StorageKeyID, http.StatusOK, and record add db msg.", func() {
+			It("Should return StorageKeyID and no error.", func() {
 				for _, element := range data {
-					serviceVal := respsr.CreateRecord(element.Response)
-					Expect(serviceVal.StorageKeyID).To(Equal(element.ServiceValidation.StorageKeyID))
-					Expect(serviceVal.Status).To(Equal(element.ServiceValidation.Status))
-					Expect(serviceVal.Msg).To(Equal(element.ServiceValidation.Msg))
+					id, err := respsr.CreateRecord(element.Response)
+					Expect(id).To(Equal(element.ID))
+					Expect(err).NotTo(HaveOccurred())
 				}
 			})
 		})
@@ -59,31 +61,34 @@ var _ = Describe("Responding Service", func() {
 			BeforeEach(func() { // Configuration
 				data = []testContent{
 					{
-						Response{-1, "abcdefegh", 0.342},
-						ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("StorageKeyID must be greater or equal 0.\n")},
+						Response{StorageKeyID: "", Content: "abcdefegh", Duration: 0.342},
+						-1, &failure.Validation{Field: "id", Msg: "StorageKeyID must not be empty."},
+					},
+					{
+						Response{StorageKeyID: "1", Content: "abc", Data: []byte("abc"), Duration: 0.342},
+						-1, &failure.Validation{Field: "content", Msg: "Response must have either text or binary body."},
 					},
 					{
-						Response{1, "", 0.342},
-						ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("Response string must be in range (0, 102402) characters.\n")},
+						Response{StorageKeyID: "1", Content: "", Duration: 0.342},
+						-1, &failure.Validation{Field: "content", Msg: "Response string must be in range (0, 102402) characters."},
 					},
 					{
-						Response{1, "null", 5.1},
-						ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("Response duration cannot be longer than 5s.\n")},
+						Response{StorageKeyID: "1", Content: "null", Duration: 5.1},
+						-1, &failure.Validation{Field: "duration", Msg: "Response duration cannot be longer than 5s."},
 					},
 					{
-						Response{1, "abcdefgh", 5.1},
-						ServiceValidation{-1, http.StatusBadRequest, fmt.Sprintf("Response duration longer than 5s should return null as content.\n")},
+						Response{StorageKeyID: "1", Content: "abcdefgh", Duration: 5.1},
+						-1, &failure.Validation{Field: "content", Msg: "Response duration longer than 5s should return null as content."},
 					},
 				}
 			})
 
 			Context("When response content is invalid.", func() {
-				It("Should return StorageKeyID as -1, http.StatusBadRequest, and record add db msg.", func() {
+				It("Should return StorageKeyID as -1 and validation error.", func() {
 					for _, element := range data {
-						serviceVal := respsr.CreateRecord(element.Response)
-						Expect(serviceVal.StorageKeyID).To(Equal(element.ServiceValidation.StorageKeyID))
-						Expect(serviceVal.Status).To(Equal(element.ServiceValidation.Status))
-						Expect(serviceVal.Msg).To(Equal(element.ServiceValidation.Msg))
+						id, err := respsr.CreateRecord(element.Response)
+						Expect(id).To(Equal(element.ID))
+						Expect(err).To(Equal(element.Err))
 					}
 				})
 			})
@@ -0,0 +1,112 @@
+package load
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/golang/gddo/httputil/header"
+)
+
+// Media types of bulk payload.
+const (
+	MediaJSON   = "application/json"     // JSON array of fetch definitions
+	MediaNDJSON = "application/x-ndjson" // fetch definition per line
+)
+
+// Bulk payload limits.
+const (
+	MaxBulkItems = 1000
+	MaxBulkBytes = 10 << 20 // 10MB
+)
+
+// BulkItem is a single fetch definition decoded from bulk payload.
+// Result status is http.StatusAccepted if the definition is well formed.
+type BulkItem struct {
+	Body   JSONPostBody
+	Result PayloadValidationError
+}
+
+// BulkPayloadCheck decodes JSON array or NDJSON of fetch definitions
+// depending on Content-Type (JSON array if not set). Definitions with
+// invalid or unknown fields are reported in their item Result, payload
+// which cannot be decoded at all is reported with returned error.
+func BulkPayloadCheck(w http.ResponseWriter, r *http.Request) ([]BulkItem, PayloadValidationError) {
+	media := MediaJSON
+	if r.Header.Get("Content-Type") != "" {
+		media, _ = header.ParseValueAndParams(r.Header, "Content-Type")
+	}
+	if media != MediaJSON && media != MediaNDJSON {
+		return nil, PayloadValidationError{Status: http.StatusUnsupportedMediaType, Code: CodeUnsupportedMediaType, Msg: fmt.Sprintln("Invalid Content-Type, application/json or application/x-ndjson expected.")}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBulkBytes)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields() // Unwanted fields check
+
+	if media == MediaJSON {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, bulkDecodeReport(err)
+		}
+		if tok != json.Delim('[') {
+			return nil, PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: fmt.Sprintln("Request body must contain JSON array of fetch definitions.")}
+		}
+	}
+
+	items := []BulkItem{}
+	for {
+		if media == MediaJSON && !dec.More() {
+			break
+		}
+		if len(items) == MaxBulkItems {
+			txt := fmt.Sprintf("Request body must not contain more than %d fetch definitions.\n", MaxBulkItems)
+			return nil, PayloadValidationError{Status: http.StatusRequestEntityTooLarge, Code: CodePayloadTooLarge, Msg: txt}
+		}
+
+		var item BulkItem
+		err := dec.Decode(&item.Body)
+		if media == MediaNDJSON && errors.Is(err, io.EOF) {
+			break
+		}
+
+		var unmarshalTypeError *json.UnmarshalTypeError
+		switch {
+		case err == nil:
+			item.Result = item.Body.Validate()
+		case errors.As(err, &unmarshalTypeError), strings.HasPrefix(err.Error(), "json: unknown field "):
+			item.Result = item.Body.DecodeHandleReport(err) // value was consumed, next one can be decoded
+		default:
+			return nil, bulkDecodeReport(err)
+		}
+		items = append(items, item)
+	}
+
+	if media == MediaJSON {
+		if _, err := dec.Token(); err != nil { // closing bracket
+			return nil, bulkDecodeReport(err)
+		}
+		if dec.More() {
+			return nil, PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: fmt.Sprintln("Request body must contain only single JSON array.")}
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, PayloadValidationError{Status: http.StatusBadRequest, Code: CodeEmptyBody, Msg: fmt.Sprintln("Request body must contain at least one fetch definition.")}
+	}
+
+	txt := fmt.Sprintln("Bulk payload check validation was succed.")
+	return items, PayloadValidationError{Status: http.StatusAccepted, Msg: txt}
+}
+
+// bulkDecodeReport describes error which stopped bulk payload decoding.
+func bulkDecodeReport(err error) PayloadValidationError {
+	if err.Error() == "http: request body too large" {
+		txt := fmt.Sprintf("Request body must not be larger than %dMB.\n", MaxBulkBytes>>20)
+		return PayloadValidationError{Status: http.StatusRequestEntityTooLarge, Code: CodePayloadTooLarge, Msg: txt}
+	}
+	return new(JSONPostBody).DecodeHandleReport(err)
+}
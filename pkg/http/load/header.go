@@ -9,15 +9,33 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/gobuzz/pkg/domain/adding"
 	"github.com/golang/gddo/httputil/header"
 )
 
+// Machine-readable codes of payload validation errors.
+const (
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeInvalidJSON          = "invalid_json"
+	CodeInvalidField         = "invalid_field"
+	CodeUnknownField         = "unknown_field"
+	CodeMissingField         = "missing_field"
+	CodeEmptyBody            = "empty_body"
+	CodePayloadTooLarge      = "payload_too_large"
+	CodeInternal             = "internal"
+)
+
 // JSONPostBody represents an HTTP fetch request recived by server
 // from the client. Field describes value of each key.
 // Used for decoding request body operation.
 type JSONPostBody struct {
-	URL      *string `json:"url"`
-	Interval *int    `json:"interval"`
+	Name       string             `json:"name"`
+	URL        *string            `json:"url"`
+	Interval   *int               `json:"interval"`
+	Assertions []adding.Check     `json:"assertions"`
+	Extractors []adding.Extractor `json:"extractors"`
+	Retention  *adding.Retention  `json:"retention"`
+	Tags       []string           `json:"tags"`
 }
 
 // Validate reports wether sending JSON payload has valid structure
@@ -28,13 +46,13 @@ func (j *JSONPostBody) Validate() PayloadValidationError {
 	switch {
 	case j.URL == nil && j.Interval == nil:
 		txt := fmt.Sprintln("Missing url and interval fields in JSON payload.")
-		return PayloadValidationError{Status: http.StatusBadRequest, Msg: txt}
+		return PayloadValidationError{Status: http.StatusBadRequest, Code: CodeMissingField, Field: "url", Msg: txt}
 	case j.URL == nil:
 		txt := fmt.Sprintln("Missing url field in JSON payload.")
-		return PayloadValidationError{Status: http.StatusBadRequest, Msg: txt}
+		return PayloadValidationError{Status: http.StatusBadRequest, Code: CodeMissingField, Field: "url", Msg: txt}
 	case j.Interval == nil:
 		txt := fmt.Sprintln("Missing interval field in JSON payload.")
-		return PayloadValidationError{Status: http.StatusBadRequest, Msg: txt}
+		return PayloadValidationError{Status: http.StatusBadRequest, Code: CodeMissingField, Field: "interval", Msg: txt}
 	}
 	txt := fmt.Sprintln("url, interval fields validation was succed.")
 	return PayloadValidationError{Status: http.StatusAccepted, Msg: txt}
@@ -57,32 +75,32 @@ func (j *JSONPostBody) DecodeHandleReport(err error) PayloadValidationError {
 		// Go 1.13 - https://blog.golang.org/go1.13-errors
 		case errors.As(err, &syntaxError):
 			txt := fmt.Sprintf("Request body contains badly-formed JSON (at position %d).\n", syntaxError.Offset)
-			return PayloadValidationError{Status: http.StatusBadRequest, Msg: txt}
+			return PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: txt}
 
 		case errors.Is(err, io.ErrUnexpectedEOF):
 			txt := fmt.Sprintln("Request body contains badly-formed JSON.")
-			return PayloadValidationError{Status: http.StatusBadRequest, Msg: txt}
+			return PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: txt}
 
 		case errors.As(err, &unmarshalTypeError):
 			txt := fmt.Sprintf("Request body contains an invalid value for the %q field (at position %d).\n", unmarshalTypeError.Field, unmarshalTypeError.Offset)
-			return PayloadValidationError{Status: http.StatusBadRequest, Msg: txt}
+			return PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidField, Field: unmarshalTypeError.Field, Msg: txt}
 
 		case strings.HasPrefix(err.Error(), "json: unknown field "):
 			fieldName := strings.TrimPrefix(err.Error(), "json: unknown field ")
 			txt := fmt.Sprintf("Request body contains unknown field %s.\n", fieldName)
-			return PayloadValidationError{Status: http.StatusBadRequest, Msg: txt}
+			return PayloadValidationError{Status: http.StatusBadRequest, Code: CodeUnknownField, Field: strings.Trim(fieldName, `"`), Msg: txt}
 
 		case errors.Is(err, io.EOF):
 			txt := fmt.Sprintln("Request body must not be empty.")
-			return PayloadValidationError{Status: http.StatusBadRequest, Msg: txt}
+			return PayloadValidationError{Status: http.StatusBadRequest, Code: CodeEmptyBody, Msg: txt}
 
 		case err.Error() == "http: request body too large":
 			txt := fmt.Sprintln("Request body must not be larger than 1MB.")
-			return PayloadValidationError{Status: http.StatusRequestEntityTooLarge, Msg: txt}
+			return PayloadValidationError{Status: http.StatusRequestEntityTooLarge, Code: CodePayloadTooLarge, Msg: txt}
 
 		default:
 			log.Println(err.Error())
-			return PayloadValidationError{Status: http.StatusInternalServerError, Msg: http.StatusText(http.StatusInternalServerError)}
+			return PayloadValidationError{Status: http.StatusInternalServerError, Code: CodeInternal, Msg: http.StatusText(http.StatusInternalServerError)}
 		}
 	}
 
@@ -91,9 +109,12 @@ func (j *JSONPostBody) DecodeHandleReport(err error) PayloadValidationError {
 }
 
 // PayloadValidationError represetns response body sending to client
-// when any error occurs during PostPayloadCheck run.
+// when any error occurs during PostPayloadCheck run. Code is one of
+// Code* constants, Field names JSON field causing the error if known.
 type PayloadValidationError struct {
 	Status int
+	Code   string
+	Field  string
 	Msg    string
 }
 
@@ -108,7 +129,7 @@ func (p *PayloadValidationError) Error() string {
 func PostPayloadCheck(w http.ResponseWriter, r *http.Request, content *JSONPostBody) PayloadValidationError {
 	if r.Header.Get("Content-Type") != "" {
 		if val, _ := header.ParseValueAndParams(r.Header, "Content-Type"); val != "application/json" {
-			return PayloadValidationError{Status: http.StatusUnsupportedMediaType, Msg: fmt.Sprintln("Invalid or lack of Content-Type.")}
+			return PayloadValidationError{Status: http.StatusUnsupportedMediaType, Code: CodeUnsupportedMediaType, Msg: fmt.Sprintln("Invalid or lack of Content-Type.")}
 		}
 	}
 
@@ -125,7 +146,7 @@ func PostPayloadCheck(w http.ResponseWriter, r *http.Request, content *JSONPostB
 	// Extraneous json data in request body
 	if dec.More() {
 		txt := fmt.Sprintln("Request body must cotain only single JSON object.")
-		return PayloadValidationError{Status: http.StatusBadRequest, Msg: txt}
+		return PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: txt}
 	}
 
 	// Content validation
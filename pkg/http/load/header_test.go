@@ -39,6 +39,11 @@ var _ = Describe("When calling PostPayloadCheck", func() {
 				PayloadValidationError{Status: http.StatusAccepted, Msg: fmt.Sprintln("Payload check validation was succed.")},
 				"application/json",
 			},
+			{
+				strings.NewReader(`{"url": "https://httpbin.org/range/15","interval":60, "assertions": [{"type": "contains", "value": "abc"}]}`),
+				PayloadValidationError{Status: http.StatusAccepted, Msg: fmt.Sprintln("Payload check validation was succed.")},
+				"application/json",
+			},
 		}
 	})
 
@@ -59,77 +64,77 @@ var _ = Describe("When calling PostPayloadCheck", func() {
 			data = []testContent{
 				{
 					strings.NewReader(`{"url": "https://httpbin.org/range/40"}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Missing interval field in JSON payload.")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeMissingField, Field: "interval", Msg: fmt.Sprintln("Missing interval field in JSON payload.")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{"url": "https://httpbin.org/range/40", "interval":10}Woops!'`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Request body must cotain only single JSON object.")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: fmt.Sprintln("Request body must cotain only single JSON object.")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{"url": 1234, "interval": ""}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln(`Request body contains an invalid value for the "url" field (at position 12).`)},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidField, Field: "url", Msg: fmt.Sprintln(`Request body contains an invalid value for the "url" field (at position 12).`)},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{"url": "http://httpbin.org/range/15","interval":"abc"}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln(`Request body contains an invalid value for the "interval" field (at position 54).`)},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidField, Field: "interval", Msg: fmt.Sprintln(`Request body contains an invalid value for the "interval" field (at position 54).`)},
 					"application/json",
 				},
 				{
 					strings.NewReader(``),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Request body must not be empty.")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeEmptyBody, Msg: fmt.Sprintln("Request body must not be empty.")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Missing url and interval fields in JSON payload.")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeMissingField, Field: "url", Msg: fmt.Sprintln("Missing url and interval fields in JSON payload.")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{"interval":10}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Missing url field in JSON payload.")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeMissingField, Field: "url", Msg: fmt.Sprintln("Missing url field in JSON payload.")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{interval:""}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Request body contains badly-formed JSON (at position 2).")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: fmt.Sprintln("Request body contains badly-formed JSON (at position 2).")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{interval:}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Request body contains badly-formed JSON (at position 2).")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: fmt.Sprintln("Request body contains badly-formed JSON (at position 2).")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{"url":"abc"}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Missing interval field in JSON payload.")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeMissingField, Field: "interval", Msg: fmt.Sprintln("Missing interval field in JSON payload.")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{url:""}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Request body contains badly-formed JSON (at position 2).")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: fmt.Sprintln("Request body contains badly-formed JSON (at position 2).")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{url:}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln("Request body contains badly-formed JSON (at position 2).")},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeInvalidJSON, Msg: fmt.Sprintln("Request body contains badly-formed JSON (at position 2).")},
 					"application/json",
 				},
 				{
 					strings.NewReader(`{"url": "https://httpbin.org/range/15","interval":60, "zonk": "Hello!"}`),
-					PayloadValidationError{Status: http.StatusBadRequest, Msg: fmt.Sprintln(`Request body contains unknown field "zonk".`)},
+					PayloadValidationError{Status: http.StatusBadRequest, Code: CodeUnknownField, Field: "zonk", Msg: fmt.Sprintln(`Request body contains unknown field "zonk".`)},
 					"application/json",
 				},
 				{ // Invalid Content-Types:
 					strings.NewReader(`{"url": "https://httpbin.org/range/15","interval":60, "zonk": "Hello!"}`),
-					PayloadValidationError{Status: http.StatusUnsupportedMediaType, Msg: fmt.Sprintln("Invalid or lack of Content-Type.")},
+					PayloadValidationError{Status: http.StatusUnsupportedMediaType, Code: CodeUnsupportedMediaType, Msg: fmt.Sprintln("Invalid or lack of Content-Type.")},
 					"text/html",
 				},
 				{
 					strings.NewReader(`{"url": "https://httpbin.org/range/15","interval":60, "zonk": "Hello!"}`),
-					PayloadValidationError{Status: http.StatusUnsupportedMediaType, Msg: fmt.Sprintln("Invalid or lack of Content-Type.")},
+					PayloadValidationError{Status: http.StatusUnsupportedMediaType, Code: CodeUnsupportedMediaType, Msg: fmt.Sprintln("Invalid or lack of Content-Type.")},
 					"application/x-www-form-urlencoded",
 				},
 			}
@@ -143,6 +148,8 @@ var _ = Describe("When calling PostPayloadCheck", func() {
 				result := fakeHandler(w, r)
 				Expect(result.Status).To(Equal(el.validationResult.Status))
 				Expect(result.Error()).To(Equal(el.validationResult.Msg))
+				Expect(result.Code).To(Equal(el.validationResult.Code))
+				Expect(result.Field).To(Equal(el.validationResult.Field))
 			}
 		})
 	})
@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/http/rest/handlers"
+)
+
+// authenticate returns middleware authenticating each request with API
+// key sent in "Authorization: Bearer <key>" or "X-API-Key" header.
+// Authenticated key is passed to next handlers with request context.
+func authenticate(auth authenticating.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret := r.Header.Get("X-API-Key")
+			if h := r.Header.Get("Authorization"); secret == "" && len(h) > 7 && strings.EqualFold(h[:7], "bearer ") {
+				secret = strings.TrimSpace(h[7:])
+			}
+
+			key, err := auth.Authenticate(secret)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="gobuzz"`)
+				handlers.WriteFailure(w, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(authenticating.WithKey(r.Context(), key)))
+		})
+	}
+}
+
+// requireScope returns middleware rejecting requests authenticated
+// with API key which does not grant scope.
+func requireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, ok := authenticating.KeyFrom(r.Context())
+			if !ok || !key.HasScope(scope) {
+				msg := fmt.Sprintf("API key lacks required scope %q.", scope)
+				handlers.WriteError(w, http.StatusForbidden, handlers.Error{Code: handlers.CodeForbidden, Message: msg, Details: map[string]string{"scope": scope}})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+	. "github.com/gobuzz/pkg/http/rest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API key authentication", func() {
+	var (
+		handler http.Handler
+		auth    authenticating.Service
+		secrets map[string]string
+	)
+
+	BeforeEach(func() { // Configuration
+		auth = authenticating.NewService(new(authenticating.FakeRepositoryKeys))
+		secrets = make(map[string]string)
+		for _, scope := range []string{authenticating.ScopeFetchersRead, authenticating.ScopeFetchersWrite, authenticating.ScopeAdmin} {
+			_, secret, _ := auth.CreateKey(scope, "", []string{scope})
+			secrets[scope] = secret
+		}
+	})
+
+	JustBeforeEach(func() {
+		handler = ServHandler(Services{ // Creation
+			Adder:     adding.NewService(new(adding.FakeRepositoryAdder), new(adding.FakeRepositoryQuotas)),
+			Responder: responding.NewService(new(responding.FakeRepositoryAdder)),
+			Lister:    listing.NewService(new(listing.FakeRepositoryLister), new(listing.FakeRepositoryFetches)),
+			Compactor: compacting.NewService(new(compacting.FakeRepositoryCompactor), compacting.Policy{}),
+			Auth:      auth,
+		})
+	})
+
+	serve := func(method, path, secret string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(`{"url":"woops","interval":10}`))
+		if secret != "" {
+			r.Header.Set("Authorization", "Bearer "+secret)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	Context("When API key is missing or invalid.", func() {
+		It("Should return http.StatusUnauthorized with WWW-Authenticate header.", func() {
+			for _, secret := range []string{"", "gbz_woops"} {
+				w := serve(http.MethodGet, "/api/fetcher/"+adding.FakeID+"/history", secret)
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Header().Get("WWW-Authenticate")).To(Equal(`Bearer realm="gobuzz"`))
+			}
+		})
+	})
+
+	Context("When API key lacks required scope.", func() {
+		It("Should return http.StatusForbidden.", func() {
+			Expect(serve(http.MethodPost, "/api/fetcher", secrets[authenticating.ScopeFetchersRead]).Code).To(Equal(http.StatusForbidden))
+			Expect(serve(http.MethodGet, "/api/fetcher/"+adding.FakeID+"/history", secrets[authenticating.ScopeFetchersWrite]).Code).To(Equal(http.StatusForbidden))
+			Expect(serve(http.MethodGet, "/api/admin/keys", secrets[authenticating.ScopeFetchersRead]).Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("When API key grants required scope.", func() {
+		It("Should pass request to handler.", func() {
+			Expect(serve(http.MethodGet, "/api/fetcher/"+adding.FakeID+"/history", secrets[authenticating.ScopeFetchersRead]).Code).To(Equal(http.StatusOK))
+			Expect(serve(http.MethodPost, "/api/fetcher", secrets[authenticating.ScopeFetchersWrite]).Code).To(Equal(http.StatusBadRequest))
+			Expect(serve(http.MethodGet, "/api/fetcher/"+adding.FakeID+"/history", secrets[authenticating.ScopeAdmin]).Code).To(Equal(http.StatusOK))
+			Expect(serve(http.MethodGet, "/api/admin/keys", secrets[authenticating.ScopeAdmin]).Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("When API key belongs to other tenant.", func() {
+		It("Should not expose fetches of default tenant.", func() {
+			_, secret, _ := auth.CreateKey("team-a", "team-a", []string{authenticating.ScopeFetchersRead})
+			Expect(serve(http.MethodGet, "/api/fetcher/"+adding.FakeID+"/history", secret).Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,165 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	. "github.com/gobuzz/pkg/http/rest"
+	"github.com/gobuzz/pkg/http/rest/handlers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// bulkResponse mirrors bulk creation response body.
+type bulkResponse struct {
+	Created int `json:"created"`
+	Failed  int `json:"failed"`
+	Results []struct {
+		Index int             `json:"index"`
+		ID    string          `json:"id"`
+		Error *handlers.Error `json:"error"`
+	} `json:"results"`
+}
+
+var _ = Describe("Bulk import and export", func() {
+	var (
+		f        *fixture
+		quotaRep *adding.FakeRepositoryQuotas
+	)
+
+	BeforeEach(func() { // Configuration
+		quotaRep = new(adding.FakeRepositoryQuotas)
+	})
+
+	JustBeforeEach(func() {
+		fetches := &listing.FakeRepositoryFetches{Records: []listing.Fetch{
+			{ID: "01HZX3V6Q8J5K2M9N4P7R1S3T6", Seq: 0, URL: "https://httpbin.org/range/15", Interval: 60, Tags: []string{"prod"}, CreatedAt: time.Now()},
+			{ID: "01HZX3V6Q8J5K2M9N4P7R1S3T7", Seq: 1, URL: "https://httpbin.org/delay/3", Interval: 30, Retention: &adding.Retention{MaxRecords: 10}, CreatedAt: time.Now()},
+		}}
+		f = newFixture(nil, func(svc *Services) { // Creation
+			svc.Adder = adding.NewService(new(adding.FakeRepositoryAdder), quotaRep)
+			svc.Lister = listing.NewService(new(listing.FakeRepositoryLister), fetches)
+		})
+	})
+
+	serve := func(method, path, media, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		if media != "" {
+			r.Header.Set("Content-Type", media)
+		}
+		return f.do(r)
+	}
+
+	bulk := func(path, media, body string) (int, bulkResponse) {
+		w := serve(http.MethodPost, path, media, body)
+		var res bulkResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &res)).To(Succeed())
+		return w.Code, res
+	}
+
+	Context("When importing definitions.", func() {
+		It("Should report result of each JSON array item.", func() {
+			code, res := bulk("/api/v1/fetcher/bulk", "application/json", `[
+				{"url":"https://httpbin.org/range/15","interval":60},
+				{"url":"https://httpbin.org/range/15","interval":"60"},
+				{"url":"woops","interval":60},
+				{"url":"https://httpbin.org/range/15","interval":60,"zonk":1}
+			]`)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.Created).To(Equal(1))
+			Expect(res.Failed).To(Equal(3))
+			Expect(res.Results[0].ID).To(Equal(adding.FakeID))
+			Expect(res.Results[1].Error.Field).To(Equal("interval"))
+			Expect(res.Results[2].Error).To(Equal(&handlers.Error{Code: handlers.CodeValidation, Message: "URL path is not accepted.", Field: "url"}))
+			Expect(res.Results[3].Error.Field).To(Equal("zonk"))
+		})
+
+		It("Should accept NDJSON.", func() {
+			code, res := bulk("/api/v1/fetcher/bulk", "application/x-ndjson", `{"url":"https://httpbin.org/range/15","interval":60}
+{"url":"https://httpbin.org/delay/1","interval":60,"tags":["prod"]}
+`)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.Created).To(Equal(2))
+		})
+
+		It("Should reject payload which cannot be decoded.", func() {
+			for _, el := range []struct{ media, body string }{
+				{"application/json", `{"url":"https://httpbin.org/range/15","interval":60}`},
+				{"application/json", `[{"url":"https://httpbin.org/range/15","interval":60}`},
+				{"application/json", `[]`},
+				{"application/x-ndjson", `{"url":`},
+			} {
+				w := serve(http.MethodPost, "/api/v1/fetcher/bulk", el.media, el.body)
+				Expect(w.Code).To(Equal(http.StatusBadRequest), el.body)
+			}
+			Expect(serve(http.MethodPost, "/api/v1/fetcher/bulk", "text/csv", "url,interval").Code).To(Equal(http.StatusUnsupportedMediaType))
+		})
+
+		Context("When atomic mode is set.", func() {
+			It("Should create nothing if any definition fails.", func() {
+				code, res := bulk("/api/v1/fetcher/bulk?atomic=true", "application/json", `[
+					{"url":"https://httpbin.org/range/15","interval":60},
+					{"url":"woops","interval":60}
+				]`)
+				Expect(code).To(Equal(http.StatusBadRequest))
+				Expect(res.Created).To(Equal(0))
+				Expect(res.Results[0].Error.Code).To(Equal(handlers.CodeAborted))
+
+				code, res = bulk("/api/v1/fetcher/bulk?atomic=true", "application/json", `[
+					{"url":"https://httpbin.org/range/15","interval":60},
+					{"url":"https://httpbin.org/range/15"}
+				]`)
+				Expect(code).To(Equal(http.StatusBadRequest))
+				Expect(res.Results[0].Error.Code).To(Equal(handlers.CodeAborted))
+				Expect(res.Results[1].Error.Field).To(Equal("interval"))
+			})
+		})
+
+		Context("When batch exceeds quota.", func() {
+			BeforeEach(func() {
+				quotaRep.Default = adding.Quota{MaxFetches: 1}
+			})
+
+			It("Should respond with status of the first failure in atomic mode.", func() {
+				code, res := bulk("/api/v1/fetcher/bulk?atomic=1", "application/json", `[
+					{"url":"https://httpbin.org/range/15","interval":60},
+					{"url":"https://httpbin.org/range/20","interval":60}
+				]`)
+				Expect(code).To(Equal(http.StatusForbidden))
+				Expect(res.Results[0].Error.Code).To(Equal(handlers.CodeAborted))
+				Expect(res.Results[1].Error.Code).To(Equal(handlers.CodeQuotaExceeded))
+			})
+		})
+	})
+
+	Context("When exporting definitions.", func() {
+		It("Should dump definitions which can be imported back.", func() {
+			w := serve(http.MethodGet, "/api/v1/fetcher/export", "", "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(MatchJSON(`[
+				{"url":"https://httpbin.org/range/15","interval":60,"tags":["prod"]},
+				{"url":"https://httpbin.org/delay/3","interval":30,"retention":{"max_records":10}}
+			]`))
+
+			code, res := bulk("/api/v1/fetcher/bulk", "application/json", w.Body.String())
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.Created).To(Equal(2))
+		})
+
+		It("Should dump NDJSON on request.", func() {
+			w := serve(http.MethodGet, "/api/v1/fetcher/export?format=ndjson", "", "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Content-Type")).To(Equal("application/x-ndjson"))
+			Expect(strings.Split(strings.TrimSpace(w.Body.String()), "\n")).To(HaveLen(2))
+
+			code, res := bulk("/api/v1/fetcher/bulk", "application/x-ndjson", w.Body.String())
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.Created).To(Equal(2))
+		})
+	})
+})
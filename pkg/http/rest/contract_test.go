@@ -0,0 +1,203 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+	. "github.com/gobuzz/pkg/http/rest"
+	"github.com/gobuzz/pkg/http/rest/openapi"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// contractCase is a single request sent to check handler against spec.
+type contractCase struct {
+	method, path, body string
+	status             int
+}
+
+var _ = Describe("OpenAPI contract", func() {
+	var (
+		f    *fixture
+		doc  *openapi.Document
+		data []contractCase
+	)
+
+	const other = "01HZX3V6Q8J5K2M9N4P7R1S3T6"
+
+	BeforeEach(func() { // Configuration
+		var err error
+		doc, err = openapi.Load()
+		Expect(err).NotTo(HaveOccurred())
+
+		lister := &listing.FakeRepositoryLister{
+			Points: []listing.Point{{At: time.Now().Add(-time.Minute), Value: 1.5}},
+			Responses: []listing.Response{
+				{Seq: 1, Outcome: listing.OutcomeOK, Content: "abc", MediaType: "text/plain", Duration: 0.1, CreatedAt: 1600000000, Assertions: []responding.CheckResult{{Type: adding.CheckContains, Passed: true}}},
+				{Seq: 2, Outcome: listing.OutcomeOK, Data: []byte{0xff, 0x00}, MediaType: "image/png", Duration: 0.2, Delay: 0.5, CreatedAt: 1600000060},
+			},
+		}
+		fetches := &listing.FakeRepositoryFetches{Records: []listing.Fetch{
+			{ID: adding.FakeID, Seq: 0, Name: "front", URL: "https://httpbin.org/range/15", Interval: 60, Tags: []string{"prod"}, CreatedAt: time.Now()},
+			{ID: other, Seq: 1, URL: "https://httpbin.org/delay/3", Interval: 60, Retention: &adding.Retention{MaxRecords: 10}, CreatedAt: time.Now()},
+		}}
+		f = newFixture(nil, func(svc *Services) { // Creation
+			svc.Adder = adding.NewService(&adding.FakeRepositoryAdder{Names: map[string]string{"taken": other}, URLs: map[string]string{"https://httpbin.org/delay/3": other}, Paused: map[string]bool{adding.FakeID: true}}, new(adding.FakeRepositoryQuotas))
+			svc.Lister = listing.NewService(lister, fetches)
+		})
+
+		data = []contractCase{
+			{http.MethodPost, "/api/v1/fetcher", `{"url":"https://httpbin.org/range/15","interval":60}`, http.StatusCreated},
+			{http.MethodPost, "/api/v1/fetcher", `{"name":"front","url":"https://httpbin.org/range/15","interval":60,"assertions":[{"type":"contains","value":"abc"}],"extractors":[{"name":"n","source":"header","expr":"Age"}],"retention":{"max_records":10}}`, http.StatusCreated},
+			{http.MethodPost, "/api/v1/fetcher", `{"name":"taken","url":"https://httpbin.org/range/15","interval":60}`, http.StatusConflict},
+			{http.MethodPost, "/api/v1/fetcher?dedup=true", `{"url":"https://httpbin.org/delay/3","interval":60}`, http.StatusOK},
+			{http.MethodPost, "/api/v1/fetcher", `{"url":"https://httpbin.org/range/15"}`, http.StatusBadRequest},
+			{http.MethodPost, "/api/v1/fetcher", `{"url":"woops","interval":60}`, http.StatusBadRequest},
+			{http.MethodPost, "/api/v1/fetcher/bulk", `[{"url":"https://httpbin.org/range/15","interval":60},{"url":"https://httpbin.org/delay/2","interval":60,"tags":["prod"]}]`, http.StatusOK},
+			{http.MethodPost, "/api/v1/fetcher/bulk?atomic=true", `[{"url":"https://httpbin.org/range/15","interval":60},{"url":"woops","interval":60}]`, http.StatusBadRequest},
+			{http.MethodPost, "/api/v1/fetcher/bulk", `{"url":"https://httpbin.org/range/15","interval":60}`, http.StatusBadRequest},
+			{http.MethodPost, "/api/v1/fetcher/test", `{"url":"https://httpbin.org/range/15","interval":60,"assertions":[{"type":"contains","value":"abc"}]}`, http.StatusOK},
+			{http.MethodPost, "/api/v1/fetcher/test", `{"url":"woops","interval":60}`, http.StatusBadRequest},
+			{http.MethodGet, "/api/v1/fetcher/export", "", http.StatusOK},
+			{http.MethodGet, "/api/v1/fetcher", "", http.StatusOK},
+			{http.MethodGet, "/api/v1/fetcher?limit=1&tag=prod&url=httpbin&order=asc", "", http.StatusOK},
+			{http.MethodGet, "/api/v1/fetcher?limit=0.5", "", http.StatusBadRequest},
+			{http.MethodGet, "/api/v1/fetcher?cursor=woops", "", http.StatusBadRequest},
+			{http.MethodGet, "/api/v1/fetcher/" + adding.FakeID, "", http.StatusOK},
+			{http.MethodGet, "/api/v1/fetcher/front", "", http.StatusOK},
+			{http.MethodGet, "/api/v1/fetcher/missing", "", http.StatusNotFound},
+			{http.MethodPut, "/api/v1/fetcher/" + adding.FakeID, `{"name":"front","url":"https://httpbin.org/range/20","interval":30}`, http.StatusOK},
+			{http.MethodPut, "/api/v1/fetcher/" + adding.FakeID, `{"url":"https://httpbin.org/range/20"}`, http.StatusBadRequest},
+			{http.MethodDelete, "/api/v1/fetcher/" + other, "", http.StatusNoContent},
+			{http.MethodDelete, "/api/v1/fetcher/missing", "", http.StatusNotFound},
+			{http.MethodPost, "/api/v1/fetcher/" + adding.FakeID + "/pause?until=2100-01-01T00:00:00Z", "", http.StatusOK},
+			{http.MethodPost, "/api/v1/fetcher/" + adding.FakeID + "/pause?until=1600000000", "", http.StatusBadRequest},
+			{http.MethodPost, "/api/v1/fetcher/missing/pause", "", http.StatusNotFound},
+			{http.MethodPost, "/api/v1/fetcher/front/resume", "", http.StatusOK},
+			{http.MethodPost, "/api/v1/fetcher/front/run", "", http.StatusOK},
+			{http.MethodPost, "/api/v1/fetcher/front/run?timeout=2h", "", http.StatusBadRequest},
+			{http.MethodPost, "/api/v1/fetcher/missing/run", "", http.StatusNotFound},
+			{http.MethodGet, "/api/v1/fetcher/" + adding.FakeID + "/history", "", http.StatusOK},
+			{http.MethodGet, "/api/v1/fetcher/" + adding.FakeID + "/history?limit=1&outcome=ok&sort=duration&order=desc", "", http.StatusOK},
+			{http.MethodGet, "/api/v1/fetcher/" + adding.FakeID + "/history?sort=size", "", http.StatusBadRequest},
+			{http.MethodGet, "/api/v1/fetcher/missing/history", "", http.StatusNotFound},
+			{http.MethodGet, "/api/v1/fetcher/" + adding.FakeID + "/series/value", "", http.StatusOK},
+			{http.MethodGet, "/api/v1/fetcher/" + adding.FakeID + "/series/value?step=1m&agg=max", "", http.StatusOK},
+			{http.MethodGet, "/api/v1/fetcher/" + adding.FakeID + "/series/value?agg=woops", "", http.StatusBadRequest},
+			{http.MethodGet, "/api/v1/fetcher/" + adding.FakeID + "/series/other", "", http.StatusNotFound},
+		}
+	})
+
+	Context("When calling documented operations.", func() {
+		It("Should accept documented requests and send documented responses.", func() {
+			for _, el := range data {
+				path := strings.SplitN(el.path, "?", 2)[0]
+				op, ok := doc.Operation(el.method, path)
+				Expect(ok).To(BeTrue(), "%s %s is not documented", el.method, path)
+				if el.status < http.StatusBadRequest {
+					Expect(op.ValidateRequest([]byte(el.body))).To(Succeed())
+				}
+
+				w := f.serve(el.method, el.path, el.body)
+
+				Expect(w.Code).To(Equal(el.status), "%s %s", el.method, el.path)
+				Expect(op.ValidateResponse(w.Code, w.Body.Bytes())).To(Succeed())
+			}
+		})
+
+		It("Should respond with documented status without API key.", func() {
+			for _, el := range data {
+				path := strings.SplitN(el.path, "?", 2)[0]
+				op, _ := doc.Operation(el.method, path)
+
+				r := httptest.NewRequest(el.method, el.path, strings.NewReader(el.body))
+				w := httptest.NewRecorder()
+				f.handler.ServeHTTP(w, r)
+
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(op.ValidateResponse(w.Code, w.Body.Bytes())).To(Succeed())
+			}
+		})
+
+		It("Should cover every documented operation with successful request.", func() {
+			for _, op := range doc.Operations() {
+				covered := false
+				for _, el := range data {
+					path := strings.SplitN(el.path, "?", 2)[0]
+					if found, ok := doc.Operation(el.method, path); ok && found.Path == op.Path && el.status < http.StatusBadRequest {
+						covered = true
+					}
+				}
+				Expect(covered).To(BeTrue(), "%s %s has no contract case", op.Method, op.Path)
+			}
+		})
+	})
+
+	Context("When listing pages.", func() {
+		It("Should link the next page until the last one.", func() {
+			path, seen := "/api/v1/fetcher?limit=1&url=httpbin", 0
+			for path != "" {
+				w := f.serve(http.MethodGet, path, "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				seen++
+
+				path = ""
+				if link := w.Header().Get("Link"); link != "" {
+					Expect(link).To(HaveSuffix(`>; rel="next"`))
+					Expect(link).To(ContainSubstring("url=httpbin"))
+					path = strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`)
+				}
+			}
+			Expect(seen).To(Equal(2))
+		})
+	})
+
+	Context("When walking fetcher routes.", func() {
+		It("Should document every v1 route and alias it without version.", func() {
+			routes := make(map[string]bool)
+			err := chi.Walk(f.handler, func(method, route string, h http.Handler, mws ...func(http.Handler) http.Handler) error {
+				routes[method+" "+strings.TrimSuffix(route, "/")] = true
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			for route := range routes {
+				if strings.Contains(route, " /api/v1/fetcher") {
+					method, path := strings.SplitN(route, " ", 2)[0], strings.SplitN(route, " ", 2)[1]
+					found := false
+					for _, op := range doc.Operations() {
+						if op.Method == method && op.Path == path {
+							found = true
+						}
+					}
+					Expect(found).To(BeTrue(), "%s is not documented", route)
+				}
+			}
+			for _, op := range doc.Operations() {
+				alias := op.Method + " " + strings.Replace(op.Path, "/api/v1", "/api", 1)
+				Expect(routes).To(HaveKey(alias))
+			}
+		})
+	})
+
+	Context("When requesting the document.", func() {
+		It("Should serve it and Swagger UI without API key.", func() {
+			w := httptest.NewRecorder()
+			f.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(w.Body.Bytes()).To(Equal(openapi.Spec()))
+
+			w = httptest.NewRecorder()
+			f.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`url: "/api/openapi.json"`))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gobuzz/pkg/http/rest/handlers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fetch creation in dedup mode", func() {
+	var (
+		f     *fixture
+		first string
+	)
+
+	BeforeEach(func() { // Creation
+		f = newFixture(nil, nil)
+	})
+
+	create := func(query, key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/fetcher"+query, strings.NewReader(body))
+		if key != "" {
+			r.Header.Set(handlers.HeaderIdempotencyKey, key)
+		}
+		return f.do(r)
+	}
+
+	JustBeforeEach(func() {
+		w := create("", "", `{"url":"https://httpbin.org/range/15","interval":3600}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		first = w.Body.String()
+	})
+
+	It("Should return existing fetch of the same URL with 200.", func() {
+		w := create("?dedup=true", "", `{"url":"https://httpbin.org/range/15","interval":60}`)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(strings.Replace(first, "}", `,"existing":true}`, 1)))
+
+		w = create("?dedup=true", "", `{"url":"https://httpbin.org/range/16","interval":60}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		Expect(w.Body.String()).NotTo(ContainSubstring("existing"))
+	})
+
+	It("Should create another fetch without dedup.", func() {
+		w := create("?dedup=false", "", `{"url":"https://httpbin.org/range/15","interval":3600}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		Expect(w.Body.String()).NotTo(MatchJSON(first))
+
+		w = create("?dedup=maybe", "", `{"url":"https://httpbin.org/range/15","interval":3600}`)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("Should replay found fetch with its original status.", func() {
+		Expect(create("?dedup=true", "k1", `{"url":"https://httpbin.org/range/15","interval":60}`).Code).To(Equal(http.StatusOK))
+
+		w := create("?dedup=true", "k1", `{"url":"https://httpbin.org/range/15","interval":60}`)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get(handlers.HeaderReplayed)).To(Equal("true"))
+	})
+})
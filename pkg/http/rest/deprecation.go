@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Deprecation defines when a route alias has been deprecated and when
+// it is going to be removed. Zero Since means the alias is not
+// deprecated, zero Sunset means removal date is not known yet.
+type Deprecation struct {
+	Since  time.Time
+	Sunset time.Time
+}
+
+// deprecate returns middleware announcing deprecation of routes under
+// prefix with Deprecation (RFC 9745) and Sunset (RFC 8594) headers.
+// Link header points at the same resource under successor prefix.
+func deprecate(d Deprecation, prefix, successor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if d.Since.IsZero() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", fmt.Sprintf("@%d", d.Since.Unix()))
+			if !d.Sunset.IsZero() {
+				w.Header().Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				link := successor + strings.TrimPrefix(r.URL.Path, prefix)
+				w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, link))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	. "github.com/gobuzz/pkg/http/rest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API versioning", func() {
+	var (
+		f           *fixture
+		deprecation Deprecation
+	)
+
+	BeforeEach(func() { // Configuration
+		deprecation = Deprecation{
+			Since:  time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+			Sunset: time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC),
+		}
+	})
+
+	JustBeforeEach(func() {
+		f = newFixture(nil, func(svc *Services) { // Creation
+			svc.Lister = listing.NewService(new(listing.FakeRepositoryLister), new(listing.FakeRepositoryFetches))
+			svc.Deprecation = deprecation
+		})
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		return f.serve(http.MethodGet, path, "")
+	}
+
+	Context("When calling v1 routes.", func() {
+		It("Should serve them without deprecation headers.", func() {
+			for _, path := range []string{"/api/v1/fetcher/" + adding.FakeID + "/history", "/api/v1/admin/keys"} {
+				w := get(path)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Deprecation")).To(BeEmpty())
+				Expect(w.Header().Get("Sunset")).To(BeEmpty())
+			}
+		})
+	})
+
+	Context("When calling unversioned aliases.", func() {
+		It("Should serve them with deprecation headers and successor link.", func() {
+			w := get("/api/fetcher/" + adding.FakeID + "/history")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Deprecation")).To(Equal("@1792368000"))
+			Expect(w.Header().Get("Sunset")).To(Equal("Mon, 19 Apr 2027 00:00:00 GMT"))
+			Expect(w.Header().Get("Link")).To(Equal(`</api/v1/fetcher/` + adding.FakeID + `/history>; rel="successor-version"`))
+
+			w = get("/api/admin/keys")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Link")).To(Equal(`</api/v1/admin/keys>; rel="successor-version"`))
+		})
+
+		Context("When aliases are not deprecated.", func() {
+			BeforeEach(func() {
+				deprecation = Deprecation{}
+			})
+
+			It("Should serve them without deprecation headers.", func() {
+				w := get("/api/fetcher/" + adding.FakeID + "/history")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Deprecation")).To(BeEmpty())
+				Expect(w.Header().Get("Link")).To(BeEmpty())
+			})
+		})
+	})
+})
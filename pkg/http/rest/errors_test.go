@@ -0,0 +1,60 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/http/load"
+	. "github.com/gobuzz/pkg/http/rest"
+	"github.com/gobuzz/pkg/http/rest/handlers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSON responses", func() {
+	var f *fixture
+
+	BeforeEach(func() { // Creation
+		f = newFixture(nil, func(svc *Services) {
+			svc.Adder = adding.NewService(new(adding.FakeRepositoryAdder), new(adding.FakeRepositoryQuotas))
+		})
+	})
+
+	serve := func(method, path, body string) (*httptest.ResponseRecorder, handlers.Error) {
+		w := f.serve(method, path, body)
+		var e handlers.Error
+		json.Unmarshal(w.Body.Bytes(), &e)
+		return w, e
+	}
+
+	Context("When request fails.", func() {
+		It("Should send error envelope with machine-readable code.", func() {
+			w, e := serve(http.MethodPost, "/api/fetcher", `{"url":"https://httpbin.org/range/15"}`)
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(e).To(Equal(handlers.Error{Code: load.CodeMissingField, Message: "Missing interval field in JSON payload.", Field: "interval"}))
+
+			w, e = serve(http.MethodPost, "/api/fetcher", `{"url":"woops","interval":10}`)
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(e).To(Equal(handlers.Error{Code: handlers.CodeValidation, Message: "URL path is not accepted.", Field: "url"}))
+
+			w, e = serve(http.MethodGet, "/api/fetcher/missing/history", "")
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(e.Code).To(Equal(handlers.CodeNotFound))
+
+			w, e = serve(http.MethodGet, "/api/woops", "")
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(e.Code).To(Equal(handlers.CodeNotFound))
+		})
+	})
+
+	Context("When fetch is created.", func() {
+		It("Should send its ID as JSON.", func() {
+			w, _ := serve(http.MethodPost, "/api/fetcher", `{"url":"https://httpbin.org/range/15","interval":600}`)
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(w.Body.String()).To(MatchJSON(`{"id":"` + adding.FakeID + `"}`))
+		})
+	})
+})
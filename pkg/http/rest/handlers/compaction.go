@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/compacting"
+)
+
+// HandleCompactionReport returns space reclaimed by background
+// compactor since server start.
+func HandleCompactionReport(compactor compacting.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, http.StatusOK, compactor.Report())
+	}
+}
+
+// HandleCompactionRun runs compaction immediately and returns space
+// reclaimed by this run.
+func HandleCompactionRun(compactor compacting.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, http.StatusOK, compactor.Run())
+	}
+}
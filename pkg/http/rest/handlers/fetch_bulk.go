@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/http/load"
+	"github.com/gobuzz/pkg/http/worker"
+)
+
+// bulkResult represents outcome of a single fetch definition of bulk
+// payload. Index is the position of the definition in payload.
+type bulkResult struct {
+	Index int    `json:"index"`
+	ID    string `json:"id,omitempty"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// bulkCreated represents bulk creation response.
+type bulkCreated struct {
+	Created int          `json:"created"`
+	Failed  int          `json:"failed"`
+	Results []bulkResult `json:"results"`
+}
+
+// HandleFetchBulk creates fetches defined by JSON array or NDJSON
+// payload and returns result of each definition. With atomic=true query
+// param no fetch is created unless all of them are valid, response
+// status is then taken from the first failed definition.
+func HandleFetchBulk(adder adding.Service, workers *worker.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		atomic, err := strconv.ParseBool(r.URL.Query().Get("atomic"))
+		if err != nil && r.URL.Query().Get("atomic") != "" {
+			WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Atomic query param must be a bool value.", Field: "atomic"})
+			return
+		}
+
+		items, payloadValidation := load.BulkPayloadCheck(w, r)
+		if payloadValidation.Status != http.StatusAccepted {
+			WriteError(w, payloadValidation.Status, PayloadError(payloadValidation))
+			return
+		}
+
+		// Malformed definitions are reported as they are, the rest goes
+		// to adding service unless malformed one fails atomic batch.
+		tenant := tenantOf(r)
+		response := bulkCreated{Results: make([]bulkResult, len(items))}
+		status, malformed := http.StatusOK, false
+		fetches, index := []adding.Fetch{}, []int{}
+		for i, item := range items {
+			response.Results[i].Index = i
+			if item.Result.Status != http.StatusAccepted {
+				e := PayloadError(item.Result)
+				e.Message = strings.TrimSpace(e.Message)
+				response.Results[i].Error = &e
+				if !malformed {
+					status, malformed = item.Result.Status, true
+				}
+				continue
+			}
+			fetches = append(fetches, fetchOf(tenant, item.Body))
+			index = append(index, i)
+		}
+
+		var results []adding.Result
+		if !atomic || !malformed {
+			results = adder.CreateRecords(fetches, atomic)
+		}
+
+		for i, pos := range index {
+			switch {
+			case results == nil:
+				response.Results[pos].Error = &Error{Code: CodeAborted, Message: "Fetch not created, other definition of the batch is malformed."}
+			case results[i].Err != nil:
+				st, e := failureError(results[i].Err)
+				response.Results[pos].Error = &e
+				if status == http.StatusOK && e.Code != CodeAborted {
+					status = st
+				}
+			default:
+				response.Results[pos].ID = results[i].ID
+				workers.Start(results[i].ID, fetches[i])
+			}
+		}
+
+		for _, res := range response.Results {
+			if res.Error != nil {
+				response.Failed++
+			} else {
+				response.Created++
+			}
+		}
+		if !atomic {
+			status = http.StatusOK // partial success, see results
+		}
+		WriteJSON(w, status, response)
+	}
+}
@@ -1,48 +1,126 @@
 package handlers
 
 import (
-	"fmt"
+	"bytes"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/gobuzz/pkg/domain/adding"
-	"github.com/gobuzz/pkg/domain/responding"
+	"github.com/gobuzz/pkg/domain/replaying"
 	"github.com/gobuzz/pkg/http/load"
 	"github.com/gobuzz/pkg/http/worker"
 )
 
+// Headers of idempotent fetch creation.
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	HeaderReplayed       = "Idempotent-Replayed" // set on replayed response
+)
+
+// fetchCreated represents fetch creation response. Existing is set if
+// dedup mode returned existing fetch.
+type fetchCreated struct {
+	ID       string `json:"id"`
+	Existing bool   `json:"existing,omitempty"`
+}
+
 // HandleFetchCreate creates a single fetch and stores it in fetch repository.
-func HandleFetchCreate(adder adding.Service, respsr responding.Service) http.HandlerFunc {
+// Its Gopher is run by workers pool, with run_now=true query param its
+// first fetch runs at once. With dedup=true query param tenant fetch of
+// the same normalised URL is returned with 200 if it exists.
+// Request repeated with the same Idempotency-Key and payload gets
+// response of the first one and does not create another fetch.
+func HandleFetchCreate(adder adding.Service, replayer replaying.Service, workers *worker.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		dedup, err := strconv.ParseBool(r.URL.Query().Get("dedup"))
+		if err != nil && r.URL.Query().Get("dedup") != "" {
+			WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Dedup query param must be a bool value.", Field: "dedup"})
+			return
+		}
+		runNow, err := strconv.ParseBool(r.URL.Query().Get("run_now"))
+		if err != nil && r.URL.Query().Get("run_now") != "" {
+			WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Run_now query param must be a bool value.", Field: "run_now"})
+			return
+		}
+
+		key, idempotent := r.Header[HeaderIdempotencyKey]
+		var payload []byte
+		if idempotent {
+			// Payload is hashed as sent, check below reads it again.
+			payload, _ = ioutil.ReadAll(io.LimitReader(r.Body, 1<<20+1))
+			r.Body = ioutil.NopCloser(bytes.NewReader(payload))
+		}
 
 		var checkStruct load.JSONPostBody
 		payloadValidation := load.PostPayloadCheck(w, r, &checkStruct)
 		if payloadValidation.Status != http.StatusAccepted {
-			http.Error(w, payloadValidation.Msg, payloadValidation.Status)
+			WriteError(w, payloadValidation.Status, PayloadError(payloadValidation))
 			return
 		}
 
-		url := *checkStruct.URL
-		interval := *checkStruct.Interval
-
-		newFetch := adding.Fetch{
-			URL:      url,
-			Interval: interval,
+		tenant := tenantOf(r)
+		if idempotent {
+			rec, replayed, err := replayer.Begin(tenant, key[0], payload)
+			if err != nil {
+				WriteFailure(w, err)
+				return
+			}
+			if replayed {
+				w.Header().Set(HeaderReplayed, "true")
+				WriteJSON(w, createdStatus(!rec.Found), fetchCreated{ID: rec.ID, Existing: rec.Found})
+				return
+			}
 		}
 
-		validation := adder.CreateRecord(newFetch)
-		if validation.Status != http.StatusOK {
-			http.Error(w, validation.Msg, validation.Status)
+		newFetch := fetchOf(tenant, checkStruct)
+		id, created := "", true
+		if dedup {
+			id, created, err = adder.FindOrCreateRecord(newFetch)
+		} else {
+			id, err = adder.CreateRecord(newFetch)
+		}
+		if err != nil {
+			if idempotent {
+				replayer.Release(tenant, key[0])
+			}
+			WriteFailure(w, err)
 			return
 		}
+		if idempotent {
+			replayer.Complete(tenant, key[0], id, !created)
+		}
 
-		goph := &worker.Gopher{ // Creating Gopher for background goroutine
-			ID:       validation.StorageKeyID,
-			URL:      url,
-			Interval: interval,
+		switch {
+		case created && runNow:
+			workers.StartNow(id, newFetch)
+		case created:
+			workers.Start(id, newFetch)
 		}
+		WriteJSON(w, createdStatus(created), fetchCreated{ID: id, Existing: !created})
+	}
+}
+
+// createdStatus returns status of creation response, 200 if existing
+// fetch was returned instead.
+func createdStatus(created bool) int {
+	if created {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
 
-		msg := []byte(fmt.Sprintf(`{"id" : %d }`+"\n", validation.StorageKeyID))
-		go worker.GopherRun(goph, respsr)
-		w.Write(msg)
+// fetchOf returns tenant fetch defined by decoded payload.
+func fetchOf(tenant string, body load.JSONPostBody) adding.Fetch {
+	return adding.Fetch{
+		Tenant:     tenant,
+		Name:       body.Name,
+		URL:        *body.URL,
+		Interval:   *body.Interval,
+		Assertions: body.Assertions,
+		Extractors: body.Extractors,
+		Retention:  body.Retention,
+		Tags:       body.Tags,
 	}
 }
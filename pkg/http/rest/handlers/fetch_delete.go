@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/http/worker"
+)
+
+// HandleFetchDelete removes a single fetch and stops its Gopher.
+// Response history is left to compaction.
+func HandleFetchDelete(adder adding.Service, lister listing.Service, workers *worker.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		id, err := fetchIDOf(r, lister)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		tenant := tenantOf(r)
+		if err := adder.DeleteRecord(tenant, id); err != nil {
+			WriteFailure(w, err)
+			return
+		}
+		workers.Stop(tenant, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
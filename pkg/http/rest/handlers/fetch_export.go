@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/http/load"
+)
+
+// HandleFetchExport dumps definitions of all tenant fetches in bulk
+// payload format, so they can be imported with HandleFetchBulk. Sends
+// NDJSON if format=ndjson query param is set or client accepts only
+// application/x-ndjson, JSON array otherwise.
+func HandleFetchExport(lister listing.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		media := load.MediaJSON
+		switch r.URL.Query().Get("format") {
+		case "", "json":
+			if r.Header.Get("Accept") == load.MediaNDJSON {
+				media = load.MediaNDJSON
+			}
+		case "ndjson":
+			media = load.MediaNDJSON
+		default:
+			WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Format must be json or ndjson.", Field: "format"})
+			return
+		}
+
+		definitions := []adding.Fetch{}
+		query := listing.FetchQuery{Page: listing.Page{Limit: listing.MaxLimit}}
+		for {
+			fetches, next, err := lister.Fetches(tenantOf(r), query)
+			if err != nil {
+				WriteFailure(w, err)
+				return
+			}
+			for _, f := range fetches {
+				definitions = append(definitions, adding.Fetch{
+					URL:        f.URL,
+					Interval:   f.Interval,
+					Assertions: f.Assertions,
+					Extractors: f.Extractors,
+					Retention:  f.Retention,
+					Tags:       f.Tags,
+				})
+			}
+			if next == "" {
+				break
+			}
+			query.Cursor = next
+		}
+
+		if media == load.MediaJSON {
+			WriteJSON(w, http.StatusOK, definitions)
+			return
+		}
+
+		w.Header().Set("Content-Type", load.MediaNDJSON)
+		w.WriteHeader(http.StatusOK)
+		enc := json.NewEncoder(w) // one definition per line
+		for _, d := range definitions {
+			if err := enc.Encode(d); err != nil {
+				log.Printf("Encoding response failed: %v\n", err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
+// HandleFetchGet returns definition of a single fetch.
+func HandleFetchGet(lister listing.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		id, err := fetchIDOf(r, lister)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		fetch, err := lister.Fetch(tenantOf(r), id)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+		WriteJSON(w, http.StatusOK, fetch)
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
+// HandleFetchList returns page of tenant fetches sorted by creation.
+// Optional query params: url (substring), tag, from, to (creation time
+// range), order (asc or desc), limit and cursor. Link header points at
+// the next page.
+func HandleFetchList(lister listing.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		params := r.URL.Query()
+		page, from, to, e := parsePage(params)
+		if e != nil {
+			WriteError(w, http.StatusBadRequest, *e)
+			return
+		}
+
+		query := listing.FetchQuery{
+			Page: page,
+			From: from,
+			To:   to,
+			URL:  params.Get("url"),
+			Tag:  params.Get("tag"),
+		}
+		if v := params.Get("sort"); v != "" && v != listing.SortCreated {
+			WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Fetches can be sorted only by created_at.", Field: "sort"})
+			return
+		}
+
+		fetches, next, err := lister.Fetches(tenantOf(r), query)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		setNextLink(w, r, next)
+		WriteJSON(w, http.StatusOK, fetches)
+	}
+}
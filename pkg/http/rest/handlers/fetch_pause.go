@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/http/worker"
+)
+
+// HandleFetchPause stops Gopher of a single fetch until it is resumed, or
+// until time given by until query param, and returns the fetch.
+// Definition and history of the fetch are kept.
+func HandleFetchPause(adder adding.Service, lister listing.Service, workers *worker.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		id, err := fetchIDOf(r, lister)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		var until time.Time
+		if v := r.URL.Query().Get("until"); v != "" {
+			if until, err = parseTime(v); err != nil {
+				WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Invalid until query param.", Field: "until"})
+				return
+			}
+		}
+
+		tenant := tenantOf(r)
+		if err := adder.PauseRecord(tenant, id, until); err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		fetch, err := lister.Fetch(tenant, id)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+		workers.Pause(id, fetch.Definition(tenant), until)
+		WriteJSON(w, http.StatusOK, fetch)
+	}
+}
+
+// HandleFetchResume runs Gopher of a paused fetch again and returns the
+// fetch. Resuming fetch which is not paused is a conflict.
+func HandleFetchResume(adder adding.Service, lister listing.Service, workers *worker.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		id, err := fetchIDOf(r, lister)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		tenant := tenantOf(r)
+		if err := adder.ResumeRecord(tenant, id); err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		fetch, err := lister.Fetch(tenant, id)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+		if !workers.Resume(id, fetch.Definition(tenant)) { // stored pause only
+			workers.Start(id, fetch.Definition(tenant))
+		}
+		WriteJSON(w, http.StatusOK, fetch)
+	}
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
+// fetchIDOf returns ID of the request tenant fetch referenced in URL
+// path either by its ID or by its name.
+func fetchIDOf(r *http.Request, lister listing.Service) (string, error) {
+	return lister.Resolve(tenantOf(r), chi.URLParam(r, "id"))
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/http/worker"
+)
+
+// HandleFetchRun fetches a single fetch at once and returns its response,
+// stored in history as manual one. Gopher of the fetch keeps its schedule.
+// Optional timeout query param limits waiting for the result.
+func HandleFetchRun(lister listing.Service, workers *worker.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		id, err := fetchIDOf(r, lister)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		timeout := worker.RunTimeout
+		if v := r.URL.Query().Get("timeout"); v != "" {
+			timeout, err = parseDuration(v)
+			if err != nil || timeout <= 0 || timeout > worker.MaxRunTimeout {
+				WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Timeout query param must be a duration up to 1m.", Field: "timeout"})
+				return
+			}
+		}
+
+		tenant := tenantOf(r)
+		fetch, err := lister.Fetch(tenant, id)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		res := workers.Run(ctx, id, fetch.Definition(tenant))
+		switch {
+		case res.Status == http.StatusGatewayTimeout:
+			WriteError(w, http.StatusGatewayTimeout, Error{Code: CodeTimeout, Message: res.Msg})
+			return
+		case res.Response == nil:
+			WriteError(w, http.StatusBadGateway, Error{Code: CodeFetchFailed, Message: res.Msg})
+			return
+		}
+		WriteJSON(w, http.StatusOK, historyItemOf(listing.ResponseOf(*res.Response, time.Now())))
+	}
+}
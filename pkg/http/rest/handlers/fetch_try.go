@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+	"github.com/gobuzz/pkg/http/load"
+	"github.com/gobuzz/pkg/http/worker"
+)
+
+// fetchTried represents dry run result sent back to the client. Status
+// and headers are of upstream response, status is 0 if URL was not
+// fetched. Text body is sent inline, binary body as base64.
+type fetchTried struct {
+	Status     int                      `json:"status"`
+	Headers    http.Header              `json:"headers,omitempty"`
+	Response   string                   `json:"response"`
+	Encoding   string                   `json:"encoding"`
+	Truncated  bool                     `json:"truncated,omitempty"`
+	MediaType  string                   `json:"media_type,omitempty"`
+	Duration   float64                  `json:"duration"`
+	Delay      float64                  `json:"limiter_delay"`
+	Outcome    string                   `json:"outcome"`
+	Error      string                   `json:"error,omitempty"`
+	Assertions []responding.CheckResult `json:"assertions,omitempty"`
+}
+
+// HandleFetchTry validates fetch definition like creation does and
+// fetches it once, without storing anything or running a Gopher. The
+// result is waited for worker.RunTimeout, body is truncated to
+// worker.MaxTryBody bytes.
+func HandleFetchTry(adder adding.Service, workers *worker.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var checkStruct load.JSONPostBody
+		payloadValidation := load.PostPayloadCheck(w, r, &checkStruct)
+		if payloadValidation.Status != http.StatusAccepted {
+			WriteError(w, payloadValidation.Status, PayloadError(payloadValidation))
+			return
+		}
+
+		fetch := fetchOf(tenantOf(r), checkStruct)
+		if err := adder.Validate(fetch); err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), worker.RunTimeout)
+		defer cancel()
+		res := workers.Try(ctx, fetch)
+		if res.Status == http.StatusGatewayTimeout {
+			WriteError(w, http.StatusGatewayTimeout, Error{Code: CodeTimeout, Message: res.Msg})
+			return
+		}
+		WriteJSON(w, http.StatusOK, triedOf(res))
+	}
+}
+
+// triedOf returns dry run result of fetch result res.
+func triedOf(res worker.GopherValidationStatus) fetchTried {
+	tried := fetchTried{Status: res.Code, Headers: res.Header, Encoding: "text"}
+	if res.Response == nil { // upstream status is not expected
+		tried.Outcome = listing.OutcomeError
+		tried.Error = res.Msg
+		return tried
+	}
+
+	rec := *res.Response
+	tried.MediaType = rec.MediaType
+	tried.Duration = rec.Duration
+	tried.Delay = rec.Delay
+	tried.Outcome = listing.Outcome(rec)
+	tried.Error = rec.Err
+	tried.Assertions = rec.Assertions
+	if rec.Err != "" {
+		return tried // body is "null" placeholder
+	}
+
+	tried.Truncated = worker.Truncate(&rec, worker.MaxTryBody)
+	tried.Response = rec.Content
+	if rec.Data != nil {
+		tried.Response = base64.StdEncoding.EncodeToString(rec.Data)
+		tried.Encoding = "base64"
+	}
+	return tried
+}
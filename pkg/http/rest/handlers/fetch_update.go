@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/http/load"
+	"github.com/gobuzz/pkg/http/worker"
+)
+
+// HandleFetchUpdate replaces definition of a single fetch with the one
+// of creation payload, restarts its Gopher and returns the stored
+// definition.
+func HandleFetchUpdate(adder adding.Service, lister listing.Service, workers *worker.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		id, err := fetchIDOf(r, lister)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		var checkStruct load.JSONPostBody
+		payloadValidation := load.PostPayloadCheck(w, r, &checkStruct)
+		if payloadValidation.Status != http.StatusAccepted {
+			WriteError(w, payloadValidation.Status, PayloadError(payloadValidation))
+			return
+		}
+
+		tenant := tenantOf(r)
+		updated := fetchOf(tenant, checkStruct)
+		if err := adder.UpdateRecord(tenant, id, updated); err != nil {
+			WriteFailure(w, err)
+			return
+		}
+		workers.Start(id, updated)
+
+		fetch, err := lister.Fetch(tenant, id)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+		WriteJSON(w, http.StatusOK, fetch)
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+)
+
+// historyItem represents single response history record sent back to
+// the client. Text body is sent inline, binary body as base64.
+type historyItem struct {
+	Response   string                   `json:"response"`
+	Encoding   string                   `json:"encoding"`
+	MediaType  string                   `json:"media_type,omitempty"`
+	Duration   float64                  `json:"duration"`
+	Delay      float64                  `json:"limiter_delay"`
+	CreatedAt  float64                  `json:"created_at"`
+	Outcome    string                   `json:"outcome"`
+	Error      string                   `json:"error,omitempty"`
+	Manual     bool                     `json:"manual,omitempty"`
+	Assertions []responding.CheckResult `json:"assertions,omitempty"`
+}
+
+// HandleHistoryGet returns page of response history of a single fetch.
+// Optional query params: from, to (creation time range), outcome (ok,
+// failed or error), sort (created_at or duration), order (asc or desc),
+// limit and cursor. Link header points at the next page.
+func HandleHistoryGet(lister listing.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		id, err := fetchIDOf(r, lister)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		params := r.URL.Query()
+		page, from, to, e := parsePage(params)
+		if e != nil {
+			WriteError(w, http.StatusBadRequest, *e)
+			return
+		}
+
+		query := listing.HistoryQuery{
+			Page:    page,
+			From:    from,
+			To:      to,
+			Outcome: params.Get("outcome"),
+			Sort:    params.Get("sort"),
+		}
+
+		history, next, err := lister.History(tenantOf(r), id, query)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		items := make([]historyItem, 0, len(history))
+		for _, h := range history {
+			items = append(items, historyItemOf(h))
+		}
+
+		setNextLink(w, r, next)
+		WriteJSON(w, http.StatusOK, items)
+	}
+}
+
+// historyItemOf returns response history record sent back to the client.
+func historyItemOf(h listing.Response) historyItem {
+	item := historyItem{
+		Response:   h.Content,
+		Encoding:   "text",
+		MediaType:  h.MediaType,
+		Duration:   h.Duration,
+		Delay:      h.Delay,
+		CreatedAt:  h.CreatedAt,
+		Outcome:    h.Outcome,
+		Error:      h.Err,
+		Manual:     h.Manual,
+		Assertions: h.Assertions,
+	}
+	if h.Data != nil {
+		item.Response = base64.StdEncoding.EncodeToString(h.Data)
+		item.Encoding = "base64"
+	}
+	return item
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/http/load"
+)
+
+// keyCreateBody represents API key creation request.
+type keyCreateBody struct {
+	Name   string   `json:"name"`
+	Tenant string   `json:"tenant"`
+	Scopes []string `json:"scopes"`
+}
+
+// keySecret represents API key sent back to the client together with
+// its secret. Secret is never returned again.
+type keySecret struct {
+	authenticating.Key
+	Secret string `json:"key"`
+}
+
+// HandleKeyList returns all API keys without their secrets.
+func HandleKeyList(auth authenticating.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, http.StatusOK, auth.Keys())
+	}
+}
+
+// HandleKeyCreate creates API key and returns it with its secret.
+func HandleKeyCreate(auth authenticating.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var body keyCreateBody
+		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // Limit to 1MB
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&body); err != nil {
+			WriteError(w, http.StatusBadRequest, Error{Code: load.CodeInvalidJSON, Message: "Request body contains badly-formed JSON."})
+			return
+		}
+
+		key, secret, err := auth.CreateKey(body.Name, body.Tenant, body.Scopes)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		WriteJSON(w, http.StatusCreated, keySecret{Key: key, Secret: secret})
+	}
+}
+
+// HandleKeyRotate replaces secret of API key and returns the new one.
+func HandleKeyRotate(auth authenticating.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, secret, err := auth.RotateKey(chi.URLParam(r, "kid"))
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		WriteJSON(w, http.StatusOK, keySecret{Key: key, Secret: secret})
+	}
+}
+
+// HandleKeyRevoke disables API key.
+func HandleKeyRevoke(auth authenticating.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := auth.RevokeKey(chi.URLParam(r, "kid"))
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
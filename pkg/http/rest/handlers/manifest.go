@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/reconciling"
+)
+
+// HandleManifestDiff returns changes which reconciling stored fetches
+// with manifest would do, without doing them.
+func HandleManifestDiff(reconciler reconciling.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		plan, err := reconciler.Plan()
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+		WriteJSON(w, http.StatusOK, plan)
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
+// parsePage reads limit, cursor and order query params of listing
+// together with from and to time range. Order is asc by default.
+func parsePage(params url.Values) (page listing.Page, from, to time.Time, e *Error) {
+	var err error
+	if v := params.Get("limit"); v != "" {
+		if page.Limit, err = strconv.Atoi(v); err != nil {
+			return page, from, to, &Error{Code: CodeValidation, Message: "Invalid limit query param.", Field: "limit"}
+		}
+	}
+	page.Cursor = params.Get("cursor")
+
+	switch params.Get("order") {
+	case "", "asc":
+	case "desc":
+		page.Desc = true
+	default:
+		return page, from, to, &Error{Code: CodeValidation, Message: "Order must be asc or desc.", Field: "order"}
+	}
+
+	if v := params.Get("from"); v != "" {
+		if from, err = parseTime(v); err != nil {
+			return page, from, to, &Error{Code: CodeValidation, Message: "Invalid from query param.", Field: "from"}
+		}
+	}
+	if v := params.Get("to"); v != "" {
+		if to, err = parseTime(v); err != nil {
+			return page, from, to, &Error{Code: CodeValidation, Message: "Invalid to query param.", Field: "to"}
+		}
+	}
+	return page, from, to, nil
+}
+
+// setNextLink points Link header at the next page of listing if
+// there is one. Other query params of the request are kept.
+func setNextLink(w http.ResponseWriter, r *http.Request, next string) {
+	if next == "" {
+		return
+	}
+	params := r.URL.Query()
+	params.Set("cursor", next)
+	w.Header().Add("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, params.Encode()))
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/http/load"
+)
+
+// Machine-readable codes of domain errors. Payload errors use load.Code*
+// constants.
+const (
+	CodeValidation       = "validation_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeRateLimited      = "rate_limited"
+	CodeQuotaExceeded    = "quota_exceeded"
+	CodeAborted          = "aborted"
+	CodeMismatch         = "idempotency_mismatch"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeFetchFailed      = "fetch_failed"
+	CodeTimeout          = "timeout"
+	CodeInternal         = load.CodeInternal
+)
+
+// Error is the envelope of every error sent back to the client.
+type Error struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Field   string      `json:"field,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// WriteJSON sends v encoded as JSON with given status code.
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Encoding response failed: %v\n", err)
+	}
+}
+
+// WriteError sends error envelope with given status code.
+func WriteError(w http.ResponseWriter, status int, e Error) {
+	e.Message = strings.TrimSpace(e.Message)
+	WriteJSON(w, status, e)
+}
+
+// PayloadError maps payload validation error into error envelope.
+func PayloadError(p load.PayloadValidationError) Error {
+	return Error{Code: p.Code, Message: p.Msg, Field: p.Field}
+}
+
+// WriteFailure sends domain error as error envelope with matching
+// status code.
+func WriteFailure(w http.ResponseWriter, err error) {
+	status, e := failureError(err)
+	if d, ok := e.Details.(map[string]int); ok && status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", strconv.Itoa(d["retry_after"]))
+	}
+	WriteError(w, status, e)
+}
+
+// failureError maps domain error into status code and error envelope.
+// Errors unknown to domain are logged and reported as internal ones.
+func failureError(err error) (int, Error) {
+	var (
+		validation   *failure.Validation
+		notFound     *failure.NotFound
+		conflict     *failure.Conflict
+		unauthorized *failure.Unauthorized
+		quota        *failure.Quota
+		busy         *failure.Busy
+		aborted      *failure.Aborted
+		mismatch     *failure.Mismatch
+	)
+
+	switch {
+	case errors.As(err, &validation):
+		return http.StatusBadRequest, Error{Code: CodeValidation, Message: validation.Msg, Field: validation.Field}
+	case errors.As(err, &notFound):
+		return http.StatusNotFound, Error{Code: CodeNotFound, Message: notFound.Msg}
+	case errors.As(err, &conflict):
+		return http.StatusConflict, Error{Code: CodeConflict, Message: conflict.Msg}
+	case errors.As(err, &unauthorized):
+		return http.StatusUnauthorized, Error{Code: CodeUnauthorized, Message: unauthorized.Msg}
+	case errors.As(err, &quota):
+		return http.StatusForbidden, Error{Code: CodeQuotaExceeded, Message: quota.Msg}
+	case errors.As(err, &busy):
+		retry := int(math.Ceil(busy.RetryAfter.Seconds()))
+		return http.StatusTooManyRequests, Error{Code: CodeRateLimited, Message: busy.Msg, Details: map[string]int{"retry_after": retry}}
+	case errors.As(err, &aborted):
+		return http.StatusConflict, Error{Code: CodeAborted, Message: aborted.Msg}
+	case errors.As(err, &mismatch):
+		return http.StatusUnprocessableEntity, Error{Code: CodeMismatch, Message: mismatch.Msg}
+	}
+	log.Printf("Request failed: %v\n", err)
+	return http.StatusInternalServerError, Error{Code: CodeInternal, Message: http.StatusText(http.StatusInternalServerError)}
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
+// HandleSeriesGet returns time series of values extracted from fetch
+// responses. Optional query params: from, to (RFC3339 or Unix seconds),
+// step (duration e.g. 1m or seconds) and agg (avg by default).
+func HandleSeriesGet(lister listing.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		id, err := fetchIDOf(r, lister)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		query := listing.SeriesQuery{To: time.Now(), Aggregation: listing.AggAvg}
+		params := r.URL.Query()
+
+		if v := params.Get("from"); v != "" {
+			if query.From, err = parseTime(v); err != nil {
+				WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Invalid from query param.", Field: "from"})
+				return
+			}
+		}
+		if v := params.Get("to"); v != "" {
+			if query.To, err = parseTime(v); err != nil {
+				WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Invalid to query param.", Field: "to"})
+				return
+			}
+		}
+		if v := params.Get("step"); v != "" {
+			if query.Step, err = parseDuration(v); err != nil {
+				WriteError(w, http.StatusBadRequest, Error{Code: CodeValidation, Message: "Invalid step query param.", Field: "step"})
+				return
+			}
+		}
+		if v := params.Get("agg"); v != "" {
+			query.Aggregation = v
+		}
+
+		series, err := lister.Series(tenantOf(r), id, chi.URLParam(r, "name"), query)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		WriteJSON(w, http.StatusOK, series)
+	}
+}
+
+// parseTime accepts RFC3339 timestamp or Unix time in seconds.
+func parseTime(v string) (time.Time, error) {
+	if sec, err := strconv.ParseFloat(v, 64); err == nil {
+		return time.Unix(0, int64(sec*float64(time.Second))), nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// parseDuration accepts Go duration format or number of seconds.
+func parseDuration(v string) (time.Duration, error) {
+	if sec, err := strconv.Atoi(v); err == nil {
+		return time.Duration(sec) * time.Second, nil
+	}
+	return time.ParseDuration(v)
+}
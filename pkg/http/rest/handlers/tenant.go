@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+)
+
+// tenantOf returns tenant of API key which authenticated the request.
+// Requests without key belong to adding.DefaultTenant.
+func tenantOf(r *http.Request) string {
+	key, ok := authenticating.KeyFrom(r.Context())
+	if !ok || key.Tenant == "" {
+		return adding.DefaultTenant
+	}
+	return key.Tenant
+}
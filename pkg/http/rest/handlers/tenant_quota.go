@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/http/load"
+)
+
+// HandleQuotaGet returns quota of a single tenant.
+func HandleQuotaGet(adder adding.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, http.StatusOK, adder.Quota(chi.URLParam(r, "tenant")))
+	}
+}
+
+// HandleQuotaSet replaces quota of a single tenant.
+func HandleQuotaSet(adder adding.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var quota adding.Quota
+		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // Limit to 1MB
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&quota); err != nil {
+			WriteError(w, http.StatusBadRequest, Error{Code: load.CodeInvalidJSON, Message: "Request body contains badly-formed JSON."})
+			return
+		}
+
+		err := adder.SetQuota(chi.URLParam(r, "tenant"), quota)
+		if err != nil {
+			WriteFailure(w, err)
+			return
+		}
+
+		WriteJSON(w, http.StatusOK, quota)
+	}
+}
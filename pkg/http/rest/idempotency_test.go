@@ -0,0 +1,79 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/http/rest/handlers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Idempotent fetch creation", func() {
+	var f *fixture
+
+	const payload = `{"url":"https://httpbin.org/range/15","interval":3600}`
+
+	BeforeEach(func() { // Creation
+		f = newFixture(nil, nil)
+	})
+
+	create := func(key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/fetcher", strings.NewReader(body))
+		if key != "" {
+			r.Header.Set(handlers.HeaderIdempotencyKey, key)
+		}
+		return f.do(r)
+	}
+
+	stored := func() int {
+		fetches, _, err := f.lister.Fetches(adding.DefaultTenant, listing.FetchQuery{Page: listing.Page{Limit: listing.MaxLimit}})
+		Expect(err).NotTo(HaveOccurred())
+		return len(fetches)
+	}
+
+	Context("When request is repeated with the same key.", func() {
+		It("Should send the original response without creating another fetch.", func() {
+			first := create("k1", payload)
+			Expect(first.Code).To(Equal(http.StatusCreated))
+			Expect(first.Header().Get(handlers.HeaderReplayed)).To(BeEmpty())
+
+			again := create("k1", payload)
+			Expect(again.Code).To(Equal(http.StatusCreated))
+			Expect(again.Header().Get(handlers.HeaderReplayed)).To(Equal("true"))
+			Expect(again.Body.String()).To(MatchJSON(first.Body.String()))
+			Expect(stored()).To(Equal(1))
+		})
+
+		It("Should reject other payload.", func() {
+			Expect(create("k1", payload).Code).To(Equal(http.StatusCreated))
+
+			w := create("k1", `{"url":"https://httpbin.org/range/20","interval":3600}`)
+			Expect(w.Code).To(Equal(http.StatusUnprocessableEntity))
+			var e handlers.Error
+			json.Unmarshal(w.Body.Bytes(), &e)
+			Expect(e.Code).To(Equal(handlers.CodeMismatch))
+			Expect(stored()).To(Equal(1))
+		})
+
+		It("Should create fetch on retry of failed request.", func() {
+			Expect(create("k1", `{"name":"01HZX3V6Q8J5K2M9N4P7R1S3T5","url":"https://httpbin.org/range/15","interval":3600}`).Code).To(Equal(http.StatusBadRequest))
+			Expect(create("k1", payload).Code).To(Equal(http.StatusCreated))
+			Expect(stored()).To(Equal(1))
+		})
+	})
+
+	Context("When requests have no or other keys.", func() {
+		It("Should create fetch for each of them.", func() {
+			Expect(create("", payload).Code).To(Equal(http.StatusCreated))
+			Expect(create("", payload).Code).To(Equal(http.StatusCreated))
+			Expect(create("k1", payload).Code).To(Equal(http.StatusCreated))
+			Expect(create("k2", payload).Code).To(Equal(http.StatusCreated))
+			Expect(stored()).To(Equal(4))
+		})
+	})
+})
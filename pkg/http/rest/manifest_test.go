@@ -0,0 +1,68 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/reconciling"
+	. "github.com/gobuzz/pkg/http/rest"
+	"github.com/gobuzz/pkg/http/rest/handlers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest diff", func() {
+	var (
+		f          *fixture
+		reconciler reconciling.Service
+	)
+
+	BeforeEach(func() { // Configuration
+		reconciler = reconciling.Service{}
+	})
+
+	JustBeforeEach(func() {
+		f = newFixture(nil, func(svc *Services) { // Creation
+			svc.Reconciler = reconciler
+		})
+	})
+
+	get := func() *httptest.ResponseRecorder {
+		return f.serve(http.MethodGet, "/api/v1/admin/manifest/diff", "")
+	}
+
+	Context("When manifest is not configured.", func() {
+		It("Should respond with not found.", func() {
+			w := get()
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(w.Body.String()).To(ContainSubstring(handlers.CodeNotFound))
+		})
+	})
+
+	Context("When manifest is configured.", func() {
+		var runner *reconciling.FakeRunner
+
+		BeforeEach(func() {
+			runner = new(reconciling.FakeRunner)
+			manifest := &reconciling.FakeRepositoryManifest{Declared: reconciling.Manifest{Fetches: []adding.Fetch{
+				{Name: "front", URL: "https://httpbin.org/range/15", Interval: 60},
+			}}}
+			adder := adding.NewService(new(adding.FakeRepositoryAdder), new(adding.FakeRepositoryQuotas))
+			reconciler = reconciling.NewService(manifest, new(reconciling.FakeRepositoryFetches), adder, runner)
+		})
+
+		It("Should report changes without applying them.", func() {
+			w := get()
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var plan reconciling.Plan
+			Expect(json.Unmarshal(w.Body.Bytes(), &plan)).To(Succeed())
+			Expect(plan.Changes).To(HaveLen(1))
+			Expect(plan.Changes[0].Action).To(Equal(reconciling.ActionCreate))
+			Expect(plan.Changes[0].Name).To(Equal("front"))
+			Expect(runner.Started).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,306 @@
+// Package openapi publishes OpenAPI 3 document of the REST API and
+// validates JSON bodies against its schemas. Validation covers the
+// subset of JSON Schema used by the document: $ref, oneOf, type, enum,
+// required, properties, additionalProperties, items, maxItems, minimum,
+// maximum, pattern and date-time format.
+package openapi
+
+import (
+	_ "embed" // openapi.json
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+// Spec returns raw OpenAPI document.
+func Spec() []byte {
+	return spec
+}
+
+// Document is a parsed OpenAPI document.
+type Document struct {
+	root map[string]interface{}
+}
+
+// Operation is a single method of document path.
+type Operation struct {
+	Method string
+	Path   string // templated e.g. /api/fetcher/{id}/history
+	doc    *Document
+	op     map[string]interface{}
+}
+
+// Load parses embedded OpenAPI document.
+func Load() (*Document, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(spec, &root); err != nil {
+		return nil, fmt.Errorf("parsing openapi.json: %w", err)
+	}
+	return &Document{root: root}, nil
+}
+
+// Operations returns all document operations sorted by path and method.
+func (d *Document) Operations() []Operation {
+	var ops []Operation
+	paths, _ := d.root["paths"].(map[string]interface{})
+	for path, item := range paths {
+		methods, _ := item.(map[string]interface{})
+		for method, op := range methods {
+			if op, ok := op.(map[string]interface{}); ok {
+				ops = append(ops, Operation{Method: strings.ToUpper(method), Path: path, doc: d, op: op})
+			}
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+	return ops
+}
+
+// Operation returns document operation matching request method and
+// concrete path e.g. /api/fetcher/0/history.
+func (d *Document) Operation(method, path string) (Operation, bool) {
+	for _, op := range d.Operations() {
+		if op.Method == method && matchPath(op.Path, path) {
+			return op, true
+		}
+	}
+	return Operation{}, false
+}
+
+// matchPath reports whether path matches template segment by segment.
+func matchPath(template, path string) bool {
+	ts := strings.Split(strings.Trim(template, "/"), "/")
+	ps := strings.Split(strings.Trim(path, "/"), "/")
+	if len(ts) != len(ps) {
+		return false
+	}
+	for i := range ts {
+		if strings.HasPrefix(ts[i], "{") && strings.HasSuffix(ts[i], "}") {
+			continue
+		}
+		if ts[i] != ps[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateRequest validates JSON request body against operation request
+// schema. Operations without request body accept empty body only.
+func (o Operation) ValidateRequest(body []byte) error {
+	req, ok := o.doc.resolve(o.op["requestBody"])
+	if !ok {
+		if len(strings.TrimSpace(string(body))) != 0 {
+			return fmt.Errorf("%s %s: unexpected request body", o.Method, o.Path)
+		}
+		return nil
+	}
+	return o.validateContent(req, body, "request")
+}
+
+// ValidateResponse validates status code and JSON response body against
+// operation responses.
+func (o Operation) ValidateResponse(status int, body []byte) error {
+	responses, _ := o.op["responses"].(map[string]interface{})
+	res, ok := o.doc.resolve(responses[strconv.Itoa(status)])
+	if !ok {
+		return fmt.Errorf("%s %s: undocumented status %d", o.Method, o.Path, status)
+	}
+	return o.validateContent(res, body, "response "+strconv.Itoa(status))
+}
+
+// validateContent validates body against application/json schema of
+// request or response object.
+func (o Operation) validateContent(obj map[string]interface{}, body []byte, what string) error {
+	content, _ := obj["content"].(map[string]interface{})
+	media, ok := content["application/json"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Errorf("%s %s %s: %w", o.Method, o.Path, what, err)
+	}
+	if err := o.doc.validate(media["schema"], v, "$"); err != nil {
+		return fmt.Errorf("%s %s %s: %w", o.Method, o.Path, what, err)
+	}
+	return nil
+}
+
+// resolve follows local $ref of document object.
+func (d *Document) resolve(v interface{}) (map[string]interface{}, bool) {
+	obj, ok := v.(map[string]interface{})
+	for i := 0; ok && i < 8; i++ { // refs pointing at refs
+		ref, isRef := obj["$ref"].(string)
+		if !isRef {
+			return obj, true
+		}
+		var node interface{} = d.root
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			m, _ := node.(map[string]interface{})
+			node = m[part]
+		}
+		obj, ok = node.(map[string]interface{})
+	}
+	return obj, ok
+}
+
+// validate checks value v decoded from JSON against schema s. At is
+// the path of v used in error messages.
+func (d *Document) validate(s interface{}, v interface{}, at string) error {
+	schema, ok := d.resolve(s)
+	if !ok {
+		return nil // no schema accepts anything
+	}
+
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if d.validate(sub, v, at) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return fmt.Errorf("%s: must match exactly one schema, matched %d", at, matched)
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if e == v {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: value %v is not one of %v", at, v, enum)
+		}
+	}
+
+	switch schema["type"] {
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: must be object", at)
+		}
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := obj[name.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %q", at, name)
+			}
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		for name, value := range obj {
+			prop, ok := props[name]
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: unknown property %q", at, name)
+				}
+				continue
+			}
+			if err := d.validate(prop, value, at+"."+name); err != nil {
+				return err
+			}
+		}
+
+	case "array":
+		arr, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: must be array", at)
+		}
+		if max, ok := schema["maxItems"].(float64); ok && float64(len(arr)) > max {
+			return fmt.Errorf("%s: must not have more than %v items", at, max)
+		}
+		for i, item := range arr {
+			if err := d.validate(schema["items"], item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s: must be string", at)
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(str) {
+			return fmt.Errorf("%s: %q does not match %s", at, str, pattern)
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				return fmt.Errorf("%s: %q is not date-time", at, str)
+			}
+		}
+
+	case "integer", "number":
+		num, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("%s: must be %s", at, schema["type"])
+		}
+		if schema["type"] == "integer" && num != float64(int64(num)) {
+			return fmt.Errorf("%s: must be integer", at)
+		}
+		if min, ok := schema["minimum"].(float64); ok && num < min {
+			return fmt.Errorf("%s: must be greater or equal %v", at, min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && num > max {
+			return fmt.Errorf("%s: must be less or equal %v", at, max)
+		}
+
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: must be boolean", at)
+		}
+	}
+	return nil
+}
+
+// docsPage loads Swagger UI from CDN pointed at served document.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>gobuzz API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+<script>
+window.ui = SwaggerUIBundle({url: "%s", dom_id: "#swagger-ui"});
+</script>
+</body>
+</html>
+`
+
+// HandleSpec serves OpenAPI document.
+func HandleSpec() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(spec)
+	}
+}
+
+// HandleDocs serves Swagger UI page rendering document served at
+// specURL.
+func HandleDocs(specURL string) http.HandlerFunc {
+	page := fmt.Sprintf(docsPage, specURL)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(page))
+	}
+}
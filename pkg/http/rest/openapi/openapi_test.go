@@ -0,0 +1,63 @@
+package openapi_test
+
+import (
+	"net/http"
+
+	. "github.com/gobuzz/pkg/http/rest/openapi"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("The OpenAPI document", func() {
+	var doc *Document
+
+	BeforeEach(func() {
+		var err error
+		doc, err = Load()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("When matching operations.", func() {
+		It("Should match concrete paths against templates.", func() {
+			op, ok := doc.Operation(http.MethodGet, "/api/v1/fetcher/12/series/latency")
+			Expect(ok).To(BeTrue())
+			Expect(op.Path).To(Equal("/api/v1/fetcher/{id}/series/{name}"))
+
+			_, ok = doc.Operation(http.MethodDelete, "/api/v1/fetcher/12/history")
+			Expect(ok).To(BeFalse())
+			_, ok = doc.Operation(http.MethodGet, "/api/v1/fetcher/12/other")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("When validating request bodies.", func() {
+		It("Should reject bodies not matching schema.", func() {
+			op, _ := doc.Operation(http.MethodPost, "/api/v1/fetcher")
+			Expect(op.ValidateRequest([]byte(`{"url":"https://httpbin.org/range/15","interval":60}`))).To(Succeed())
+
+			for _, body := range []string{
+				`{"url":"https://httpbin.org/range/15"}`,
+				`{"url":"https://httpbin.org/range/15","interval":1.5}`,
+				`{"url":"https://httpbin.org/range/15","interval":0}`,
+				`{"url":"https://httpbin.org/range/15","interval":60,"zonk":1}`,
+				`{"url":"https://httpbin.org/range/15","interval":60,"assertions":[{"type":"woops"}]}`,
+				`{"url":"https://httpbin.org/range/15","interval":60,"extractors":[{"name":"a b","source":"header","expr":"Age"}]}`,
+				`[]`,
+				`{`,
+			} {
+				Expect(op.ValidateRequest([]byte(body))).NotTo(Succeed(), body)
+			}
+		})
+	})
+
+	Context("When validating responses.", func() {
+		It("Should reject undocumented statuses and bodies.", func() {
+			op, _ := doc.Operation(http.MethodGet, "/api/v1/fetcher/0/series/value")
+			Expect(op.ValidateResponse(http.StatusOK, []byte(`{"name":"value","type":"number","points":[{"t":"2020-01-01T00:00:00Z","value":1}]}`))).To(Succeed())
+			Expect(op.ValidateResponse(http.StatusOK, []byte(`{"name":"value","type":"number","points":[{"t":"yesterday","value":1}]}`))).NotTo(Succeed())
+			Expect(op.ValidateResponse(http.StatusNotFound, []byte(`{"code":"not_found","message":"Series \"x\" not found."}`))).To(Succeed())
+			Expect(op.ValidateResponse(http.StatusNotFound, []byte(`{"message":"Series \"x\" not found."}`))).NotTo(Succeed())
+			Expect(op.ValidateResponse(http.StatusTeapot, []byte(`{}`))).NotTo(Succeed())
+		})
+	})
+})
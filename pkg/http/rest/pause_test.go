@@ -0,0 +1,85 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/listing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pausing fetches", func() {
+	var (
+		f  *fixture
+		id string
+	)
+
+	BeforeEach(func() { // Creation
+		f = newFixture(nil, nil)
+	})
+
+	list := func() []listing.Fetch {
+		w := f.serve(http.MethodGet, "/api/v1/fetcher", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var fetches []listing.Fetch
+		Expect(json.Unmarshal(w.Body.Bytes(), &fetches)).To(Succeed())
+		return fetches
+	}
+
+	JustBeforeEach(func() {
+		w := f.serve(http.MethodPost, "/api/v1/fetcher", `{"name":"front","url":"https://httpbin.org/range/15","interval":3600}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var created struct{ ID string }
+		json.Unmarshal(w.Body.Bytes(), &created)
+		id = created.ID
+	})
+
+	AfterEach(func() {
+		f.workers.Stop("default", id)
+	})
+
+	It("Should stop the worker until resumed and show the state in listing.", func() {
+		Expect(f.serve(http.MethodPost, "/api/v1/fetcher/front/pause", "").Code).To(Equal(http.StatusOK))
+		Expect(f.workers.Running("default", id)).To(BeFalse())
+		Expect(list()[0].Paused).To(BeTrue())
+		Expect(list()[0].PausedUntil).To(BeNil())
+
+		w := f.serve(http.MethodPut, "/api/v1/fetcher/front", `{"name":"front","url":"https://httpbin.org/range/15","interval":600}`)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(ContainSubstring(`"paused":true`))
+		Expect(f.workers.Running("default", id)).To(BeFalse())
+
+		Expect(f.serve(http.MethodPost, "/api/v1/fetcher/front/resume", "").Code).To(Equal(http.StatusOK))
+		Expect(f.workers.Running("default", id)).To(BeTrue())
+		Expect(list()[0].Paused).To(BeFalse())
+		Expect(list()[0].Interval).To(Equal(600))
+	})
+
+	It("Should reject resuming fetch which is not paused.", func() {
+		f.workers.Stop("default", id)
+		w := f.serve(http.MethodPost, "/api/v1/fetcher/front/resume", "")
+		Expect(w.Code).To(Equal(http.StatusConflict))
+		Expect(w.Body.String()).To(ContainSubstring("is not paused"))
+		Expect(f.workers.Running("default", id)).To(BeFalse())
+	})
+
+	It("Should resume the worker at pause end.", func() {
+		until := time.Now().Add(50 * time.Millisecond).UTC()
+		w := f.serve(http.MethodPost, "/api/v1/fetcher/"+id+"/pause?until="+until.Format(time.RFC3339Nano), "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(list()[0].PausedUntil).NotTo(BeNil())
+		Expect(list()[0].PausedUntil.Equal(until)).To(BeTrue())
+
+		Eventually(func() bool { return f.workers.Running("default", id) }).Should(BeTrue())
+		Expect(list()[0].Paused).To(BeFalse())
+	})
+
+	It("Should reject pause end in the past.", func() {
+		w := f.serve(http.MethodPost, "/api/v1/fetcher/front/pause?until=2020-01-01T00:00:00Z", "")
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring("Pause end must be in the future."))
+		Expect(f.workers.Running("default", id)).To(BeTrue())
+	})
+})
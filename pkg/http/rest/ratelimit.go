@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/http/rest/handlers"
+	"github.com/gobuzz/pkg/ratelimit"
+)
+
+// RateLimit defines request rates allowed to a single client IP and
+// to a single API key. Zero rate means no limit.
+type RateLimit struct {
+	IP  ratelimit.Rate
+	Key ratelimit.Rate
+}
+
+// limitRate returns middleware rejecting requests over rate of the key
+// returned by keyOf with http.StatusTooManyRequests and Retry-After
+// header. Requests without key are passed.
+func limitRate(rate ratelimit.Rate, keyOf func(r *http.Request) string) func(http.Handler) http.Handler {
+	limiter := ratelimit.NewLimiter(rate)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := keyOf(r)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if ok, wait := limiter.Allow(key, time.Now()); !ok {
+				retry := int(math.Ceil(wait.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(retry))
+				handlers.WriteError(w, http.StatusTooManyRequests, handlers.Error{
+					Code:    handlers.CodeRateLimited,
+					Message: "Rate limit exceeded.",
+					Details: map[string]int{"retry_after": retry},
+				})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns IP address of the client.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// clientKey returns ID of API key which authenticated the request.
+func clientKey(r *http.Request) string {
+	key, _ := authenticating.KeyFrom(r.Context())
+	return key.ID
+}
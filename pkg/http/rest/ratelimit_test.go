@@ -0,0 +1,73 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+	. "github.com/gobuzz/pkg/http/rest"
+	"github.com/gobuzz/pkg/ratelimit"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rate limiting", func() {
+	var (
+		handler http.Handler
+		secrets []string
+	)
+
+	BeforeEach(func() { // Configuration
+		auth := authenticating.NewService(new(authenticating.FakeRepositoryKeys))
+		secrets = nil
+		for _, name := range []string{"a", "b"} {
+			_, secret, _ := auth.CreateKey(name, "", []string{authenticating.ScopeFetchersRead})
+			secrets = append(secrets, secret)
+		}
+		handler = ServHandler(Services{ // Creation
+			Adder:     adding.NewService(new(adding.FakeRepositoryAdder), new(adding.FakeRepositoryQuotas)),
+			Responder: responding.NewService(new(responding.FakeRepositoryAdder)),
+			Lister:    listing.NewService(new(listing.FakeRepositoryLister), new(listing.FakeRepositoryFetches)),
+			Compactor: compacting.NewService(new(compacting.FakeRepositoryCompactor), compacting.Policy{}),
+			Auth:      auth,
+			RateLimit: RateLimit{
+				IP:  ratelimit.Rate{PerSecond: 1, Burst: 3},
+				Key: ratelimit.Rate{PerSecond: 0.5, Burst: 2},
+			},
+		})
+	})
+
+	serve := func(ip, secret string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/api/fetcher/"+adding.FakeID+"/history", nil)
+		r.RemoteAddr = ip + ":1234"
+		r.Header.Set("X-API-Key", secret)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	Context("When API key exceeds its rate.", func() {
+		It("Should return http.StatusTooManyRequests with Retry-After header.", func() {
+			Expect(serve("10.0.0.1", secrets[0]).Code).To(Equal(http.StatusOK))
+			Expect(serve("10.0.0.2", secrets[0]).Code).To(Equal(http.StatusOK))
+			w := serve("10.0.0.3", secrets[0])
+			Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(w.Header().Get("Retry-After")).To(Equal("2"))
+
+			Expect(serve("10.0.0.3", secrets[1]).Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("When client IP exceeds its rate.", func() {
+		It("Should reject requests before authentication.", func() {
+			for i := 0; i < 3; i++ {
+				serve("10.0.0.1", "gbz_woops")
+			}
+			Expect(serve("10.0.0.1", secrets[0]).Code).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+})
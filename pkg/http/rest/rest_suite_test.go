@@ -0,0 +1,95 @@
+package rest_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/replaying"
+	"github.com/gobuzz/pkg/domain/responding"
+	. "github.com/gobuzz/pkg/http/rest"
+	"github.com/gobuzz/pkg/http/worker"
+	"github.com/gobuzz/pkg/storage/memory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "REST Server Suite")
+}
+
+// upstream answers fetches instead of the network.
+type upstream func(r *http.Request) (*http.Response, error)
+
+func (u upstream) RoundTrip(r *http.Request) (*http.Response, error) {
+	return u(r)
+}
+
+// answer is upstream sending text body abc to every fetch.
+func answer(r *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"text/plain"}},
+		Body:       ioutil.NopCloser(strings.NewReader("abc")),
+		Request:    r,
+	}, nil
+}
+
+// fixture is a server handler of services on memory storage called with
+// admin API key. Gophers of its workers fetch from upstream.
+type fixture struct {
+	handler *chi.Mux
+	secret  string
+	lister  listing.Service
+	workers *worker.Pool
+}
+
+// newFixture creates fixture with fetches answered by fetched, answer if
+// nil. Services set by configure replace the memory ones, workers are
+// made of Responder unless set.
+func newFixture(fetched upstream, configure func(svc *Services)) *fixture {
+	if fetched == nil {
+		fetched = answer
+	}
+
+	s := new(memory.ResponseFetch)
+	auth := authenticating.NewService(&s.Keys)
+	_, secret, _ := auth.CreateKey("ci", "", []string{authenticating.ScopeAdmin})
+	svc := Services{
+		Adder:     adding.NewService(&s.Fetches, &s.Tenants),
+		Responder: responding.NewService(&s.Responses),
+		Lister:    listing.NewService(&s.Responses, &s.Fetches),
+		Compactor: compacting.NewService(s, compacting.Policy{}),
+		Auth:      auth,
+		Replayer:  replaying.NewService(&s.Replays, 0),
+	}
+	if configure != nil {
+		configure(&svc)
+	}
+	if svc.Workers == nil {
+		svc.Workers = worker.NewPool(svc.Responder, nil, &http.Client{Transport: fetched})
+	}
+	return &fixture{handler: ServHandler(svc), secret: secret, lister: svc.Lister, workers: svc.Workers}
+}
+
+// serve sends request with body to the handler and returns the response.
+func (f *fixture) serve(method, path, body string) *httptest.ResponseRecorder {
+	return f.do(httptest.NewRequest(method, path, strings.NewReader(body)))
+}
+
+// do sends r with fixture API key to the handler and returns the
+// response.
+func (f *fixture) do(r *http.Request) *httptest.ResponseRecorder {
+	r.Header.Set("X-API-Key", f.secret)
+	w := httptest.NewRecorder()
+	f.handler.ServeHTTP(w, r)
+	return w
+}
@@ -2,22 +2,65 @@ package rest
 
 import (
 	"github.com/go-chi/chi"
-	"github.com/gobuzz/pkg/domain/adding"
-	"github.com/gobuzz/pkg/domain/responding"
+	"github.com/gobuzz/pkg/domain/authenticating"
 	"github.com/gobuzz/pkg/http/rest/handlers"
 )
 
-func (s *server) routes(adder adding.Service, respsr responding.Service) {
+func (s *server) routes(router chi.Router, svc Services) {
 
-	s.router.Route("/api/fetcher", func(r chi.Router) {
-		// r.Get("/", s.handleRequestsFetch())
-		r.Post("/", handlers.HandleFetchCreate(adder, respsr))
+	router.Route("/api/v1", func(r chi.Router) {
+		s.routesV1(r, svc)
+	})
+
+	// Unversioned aliases of v1 kept for clients from before versioning.
+	router.Group(func(r chi.Router) {
+		r.Use(deprecate(svc.Deprecation, "/api", "/api/v1"))
+		r.Route("/api/fetcher", func(r chi.Router) { s.fetcherRoutes(r, svc) })
+		r.Route("/api/admin", func(r chi.Router) { s.adminRoutes(r, svc) })
+	})
+
+}
+
+func (s *server) routesV1(router chi.Router, svc Services) {
+	router.Route("/fetcher", func(r chi.Router) { s.fetcherRoutes(r, svc) })
+	router.Route("/admin", func(r chi.Router) { s.adminRoutes(r, svc) })
+}
+
+func (s *server) fetcherRoutes(r chi.Router, svc Services) {
+	r.With(requireScope(authenticating.ScopeFetchersRead)).Get("/", handlers.HandleFetchList(svc.Lister))
+	r.With(requireScope(authenticating.ScopeFetchersWrite)).Post("/", handlers.HandleFetchCreate(svc.Adder, svc.Replayer, svc.Workers))
+	r.With(requireScope(authenticating.ScopeFetchersWrite)).Post("/bulk", handlers.HandleFetchBulk(svc.Adder, svc.Workers))
+	r.With(requireScope(authenticating.ScopeFetchersWrite)).Post("/test", handlers.HandleFetchTry(svc.Adder, svc.Workers))
+	r.With(requireScope(authenticating.ScopeFetchersRead)).Get("/export", handlers.HandleFetchExport(svc.Lister))
 
-		// r.Route("/{id}", func(r chi.Router) {
-		// 	r.Get("/", s.handleRequestFetch())
-		// 	r.Put("/", s.handleRequestUpdate())
-		// })
+	r.Route("/{id}", func(r chi.Router) {
+		r.With(requireScope(authenticating.ScopeFetchersWrite)).Put("/", handlers.HandleFetchUpdate(svc.Adder, svc.Lister, svc.Workers))
+		r.With(requireScope(authenticating.ScopeFetchersWrite)).Delete("/", handlers.HandleFetchDelete(svc.Adder, svc.Lister, svc.Workers))
+		r.With(requireScope(authenticating.ScopeFetchersWrite)).Post("/pause", handlers.HandleFetchPause(svc.Adder, svc.Lister, svc.Workers))
+		r.With(requireScope(authenticating.ScopeFetchersWrite)).Post("/resume", handlers.HandleFetchResume(svc.Adder, svc.Lister, svc.Workers))
+		r.With(requireScope(authenticating.ScopeFetchersWrite)).Post("/run", handlers.HandleFetchRun(svc.Lister, svc.Workers))
 
+		r.Group(func(r chi.Router) {
+			r.Use(requireScope(authenticating.ScopeFetchersRead))
+			r.Get("/", handlers.HandleFetchGet(svc.Lister))
+			r.Get("/history", handlers.HandleHistoryGet(svc.Lister))
+			r.Get("/series/{name}", handlers.HandleSeriesGet(svc.Lister))
+		})
 	})
+}
+
+func (s *server) adminRoutes(r chi.Router, svc Services) {
+	r.Use(requireScope(authenticating.ScopeAdmin))
+	r.Get("/compaction", handlers.HandleCompactionReport(svc.Compactor))
+	r.Post("/compaction", handlers.HandleCompactionRun(svc.Compactor))
+
+	r.Get("/keys", handlers.HandleKeyList(svc.Auth))
+	r.Post("/keys", handlers.HandleKeyCreate(svc.Auth))
+	r.Post("/keys/{kid}/rotate", handlers.HandleKeyRotate(svc.Auth))
+	r.Delete("/keys/{kid}", handlers.HandleKeyRevoke(svc.Auth))
+
+	r.Get("/tenants/{tenant}/quota", handlers.HandleQuotaGet(svc.Adder))
+	r.Put("/tenants/{tenant}/quota", handlers.HandleQuotaSet(svc.Adder))
 
+	r.Get("/manifest/diff", handlers.HandleManifestDiff(svc.Reconciler))
 }
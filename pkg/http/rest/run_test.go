@@ -0,0 +1,92 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Running fetches manually", func() {
+	var (
+		f       *fixture
+		id      string
+		fetched chan string
+	)
+
+	BeforeEach(func() { // Creation
+		fetched = make(chan string, 8)
+		f = newFixture(func(r *http.Request) (*http.Response, error) {
+			fetched <- r.URL.Path
+			if r.URL.Path == "/delay/1" {
+				<-r.Context().Done()
+				return nil, r.Context().Err()
+			}
+			return answer(r)
+		}, nil)
+	})
+
+	AfterEach(func() {
+		f.workers.Stop("default", id)
+	})
+
+	create := func(query, url string) {
+		w := f.serve(http.MethodPost, "/api/v1/fetcher"+query, `{"name":"front","url":"`+url+`","interval":3600}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var created struct{ ID string }
+		json.Unmarshal(w.Body.Bytes(), &created)
+		id = created.ID
+	}
+
+	It("Should return response at once and keep it in history as manual.", func() {
+		create("", "https://httpbin.org/range/15")
+		w := f.serve(http.MethodPost, "/api/v1/fetcher/front/run", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(ContainSubstring(`"response":"abc"`))
+		Expect(w.Body.String()).To(ContainSubstring(`"outcome":"ok"`))
+		Expect(w.Body.String()).To(ContainSubstring(`"manual":true`))
+		Expect(f.workers.Running("default", id)).To(BeTrue())
+
+		w = f.serve(http.MethodGet, "/api/v1/fetcher/front/history", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(ContainSubstring(`"manual":true`))
+	})
+
+	It("Should return empty history before the first run.", func() {
+		create("", "https://httpbin.org/range/15")
+		w := f.serve(http.MethodGet, "/api/v1/fetcher/front/history", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`[]`))
+		Expect(f.serve(http.MethodGet, "/api/v1/fetcher/01HZX3V6Q8J5K2M9N4P7R1S3T6/history", "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("Should report fetch not finished in time.", func() {
+		create("", "https://httpbin.org/delay/1")
+		w := f.serve(http.MethodPost, "/api/v1/fetcher/front/run?timeout=50ms", "")
+		Expect(w.Code).To(Equal(http.StatusGatewayTimeout))
+		Expect(w.Body.String()).To(ContainSubstring(`"code":"timeout"`))
+	})
+
+	It("Should reject invalid timeout and missing fetch.", func() {
+		create("", "https://httpbin.org/range/15")
+		Expect(f.serve(http.MethodPost, "/api/v1/fetcher/front/run?timeout=2h", "").Code).To(Equal(http.StatusBadRequest))
+		Expect(f.serve(http.MethodPost, "/api/v1/fetcher/missing/run", "").Code).To(Equal(http.StatusNotFound))
+		Consistently(fetched, "20ms").ShouldNot(Receive())
+	})
+
+	It("Should run the first fetch at creation with run_now.", func() {
+		create("?run_now=true", "https://httpbin.org/range/15")
+		Eventually(fetched).Should(Receive(Equal("/range/15")))
+		Eventually(func() string {
+			return f.serve(http.MethodGet, "/api/v1/fetcher/front/history", "").Body.String()
+		}, time.Second).Should(ContainSubstring(`"outcome":"ok"`))
+	})
+
+	It("Should reject run_now which is not a bool.", func() {
+		w := f.serve(http.MethodPost, "/api/v1/fetcher?run_now=maybe", `{"url":"https://httpbin.org/range/15","interval":3600}`)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring(`"field":"run_now"`))
+	})
+})
@@ -1,27 +1,68 @@
 package rest
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/reconciling"
+	"github.com/gobuzz/pkg/domain/replaying"
 	"github.com/gobuzz/pkg/domain/responding"
+	"github.com/gobuzz/pkg/http/rest/handlers"
+	"github.com/gobuzz/pkg/http/rest/openapi"
+	"github.com/gobuzz/pkg/http/worker"
 )
 
+// Services aggregates domain services used by server handlers.
+type Services struct {
+	Adder       adding.Service
+	Responder   responding.Service
+	Lister      listing.Service
+	Compactor   compacting.Service
+	Auth        authenticating.Service
+	RateLimit   RateLimit
+	Deprecation Deprecation         // of unversioned /api aliases
+	Hosts       *worker.HostLimiter // outbound limits shared by Gophers
+	Workers     *worker.Pool        // runs Gophers, made of Responder and Hosts if nil
+	Reconciler  reconciling.Service
+	Replayer    replaying.Service // of Idempotency-Key, nothing is kept if zero
+}
+
 type server struct {
 	router *chi.Mux
 }
 
 // ServHandler creates server handler and returns registered router
-func ServHandler(a adding.Service, r responding.Service) *chi.Mux {
-	s := newServer(a, r)
+func ServHandler(svc Services) *chi.Mux {
+	s := newServer(svc)
 	return s.router
 }
 
-func newServer(a adding.Service, r responding.Service) *server {
+func newServer(svc Services) *server {
+	if svc.Workers == nil {
+		svc.Workers = worker.NewPool(svc.Responder, svc.Hosts, nil)
+	}
 	s := &server{
 		router: chi.NewRouter(),
 	}
 	s.router.Use(middleware.Logger)
-	s.routes(a, r)
+	s.router.Use(limitRate(svc.RateLimit.IP, clientIP))
+	s.router.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		handlers.WriteError(w, http.StatusNotFound, handlers.Error{Code: handlers.CodeNotFound, Message: "Resource not found."})
+	})
+	s.router.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		handlers.WriteError(w, http.StatusMethodNotAllowed, handlers.Error{Code: handlers.CodeMethodNotAllowed, Message: "Method not allowed."})
+	})
+	s.router.Get("/api/openapi.json", openapi.HandleSpec()) // public, no API key
+	s.router.Get("/api/docs", openapi.HandleDocs("/api/openapi.json"))
+	s.router.Group(func(r chi.Router) {
+		r.Use(authenticate(svc.Auth))
+		r.Use(limitRate(svc.RateLimit.Key, clientKey))
+		s.routes(r, svc)
+	})
 	return s
 }
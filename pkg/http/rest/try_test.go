@@ -0,0 +1,74 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/listing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trying fetches", func() {
+	var f *fixture
+
+	BeforeEach(func() { // Creation
+		f = newFixture(func(r *http.Request) (*http.Response, error) {
+			res, _ := answer(r)
+			res.Header.Set("Age", "7")
+			switch r.URL.Path {
+			case "/range/5000":
+				res.Body = ioutil.NopCloser(strings.NewReader("a" + strings.Repeat("é", 2500)))
+			case "/delay/500":
+				res.StatusCode = http.StatusInternalServerError
+			}
+			return res, nil
+		}, nil)
+	})
+
+	try := func(body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		w := f.serve(http.MethodPost, "/api/v1/fetcher/test", body)
+		var tried map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &tried)
+		return w, tried
+	}
+
+	It("Should return upstream response without storing anything.", func() {
+		w, tried := try(`{"name":"front","url":"https://httpbin.org/range/15","interval":60,"assertions":[{"type":"contains","value":"abc"}]}`)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(tried["status"]).To(BeNumerically("==", http.StatusOK))
+		Expect(tried["headers"]).To(HaveKeyWithValue("Age", []interface{}{"7"}))
+		Expect(tried["response"]).To(Equal("abc"))
+		Expect(tried["outcome"]).To(Equal(listing.OutcomeOK))
+		Expect(tried).To(HaveKey("duration"))
+		Expect(tried).NotTo(HaveKey("truncated"))
+
+		Expect(f.serve(http.MethodGet, "/api/v1/fetcher", "").Body.String()).To(MatchJSON(`[]`))
+	})
+
+	It("Should truncate long body at rune start.", func() {
+		w, tried := try(`{"url":"https://httpbin.org/range/5000","interval":60}`)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(tried["truncated"]).To(BeTrue())
+		Expect(tried["response"]).To(Equal("a" + strings.Repeat("é", 2047)))
+	})
+
+	It("Should report unexpected upstream status.", func() {
+		w, tried := try(`{"url":"https://httpbin.org/delay/500","interval":60}`)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(tried["status"]).To(BeNumerically("==", http.StatusInternalServerError))
+		Expect(tried["outcome"]).To(Equal(listing.OutcomeError))
+	})
+
+	It("Should validate definition like creation.", func() {
+		w, _ := try(`{"url":"https://example.com","interval":60}`)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring("URL path is not accepted."))
+
+		w, _ = try(`{"url":"https://httpbin.org/range/15"}`)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+})
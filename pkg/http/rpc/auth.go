@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/http/rpc/fetcherpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// scopes maps full method names into API key scope they require.
+var scopes = map[string]string{
+	fetcherpb.Fetcher_CreateFetch_FullMethodName:    authenticating.ScopeFetchersWrite,
+	fetcherpb.Fetcher_GetFetch_FullMethodName:       authenticating.ScopeFetchersRead,
+	fetcherpb.Fetcher_ListFetches_FullMethodName:    authenticating.ScopeFetchersRead,
+	fetcherpb.Fetcher_UpdateFetch_FullMethodName:    authenticating.ScopeFetchersWrite,
+	fetcherpb.Fetcher_DeleteFetch_FullMethodName:    authenticating.ScopeFetchersWrite,
+	fetcherpb.Fetcher_PauseFetch_FullMethodName:     authenticating.ScopeFetchersWrite,
+	fetcherpb.Fetcher_ResumeFetch_FullMethodName:    authenticating.ScopeFetchersWrite,
+	fetcherpb.Fetcher_RunFetch_FullMethodName:       authenticating.ScopeFetchersWrite,
+	fetcherpb.Fetcher_TryFetch_FullMethodName:       authenticating.ScopeFetchersWrite,
+	fetcherpb.Fetcher_ListHistory_FullMethodName:    authenticating.ScopeFetchersRead,
+	fetcherpb.Fetcher_WatchResponses_FullMethodName: authenticating.ScopeFetchersRead,
+}
+
+// authenticate returns ctx carrying API key sent in "x-api-key" or
+// "authorization: Bearer <key>" metadata if the key grants scope
+// required by method.
+func authenticate(ctx context.Context, auth authenticating.Service, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var secret string
+	if v := md.Get("x-api-key"); len(v) > 0 {
+		secret = v[0]
+	}
+	if v := md.Get("authorization"); secret == "" && len(v) > 0 && len(v[0]) > 7 && strings.EqualFold(v[0][:7], "bearer ") {
+		secret = strings.TrimSpace(v[0][7:])
+	}
+
+	key, err := auth.Authenticate(secret)
+	if err != nil {
+		return ctx, statusOf(err)
+	}
+	scope, ok := scopes[method]
+	if !ok || !key.HasScope(scope) {
+		return ctx, status.Errorf(codes.PermissionDenied, "API key lacks required scope %q.", scope)
+	}
+	return authenticating.WithKey(ctx, key), nil
+}
+
+// unaryAuth returns interceptor authenticating unary calls.
+func unaryAuth(auth authenticating.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, auth, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authStream passes authenticated context to stream handler.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authStream) Context() context.Context { return s.ctx }
+
+// streamAuth returns interceptor authenticating streaming calls.
+func streamAuth(auth authenticating.Service) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), auth, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// tenantOf returns tenant of API key which authenticated the call.
+// Calls without key belong to adding.DefaultTenant.
+func tenantOf(ctx context.Context) string {
+	key, ok := authenticating.KeyFrom(ctx)
+	if !ok || key.Tenant == "" {
+		return adding.DefaultTenant
+	}
+	return key.Tenant
+}
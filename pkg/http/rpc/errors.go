@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gobuzz/pkg/domain/failure"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// statusOf maps domain error into gRPC status error. Invalid field is
+// sent as BadRequest detail, retry delay as RetryInfo detail. Errors
+// unknown to domain are logged and reported as internal ones.
+func statusOf(err error) error {
+	var (
+		validation   *failure.Validation
+		notFound     *failure.NotFound
+		conflict     *failure.Conflict
+		unauthorized *failure.Unauthorized
+		quota        *failure.Quota
+		busy         *failure.Busy
+		aborted      *failure.Aborted
+		mismatch     *failure.Mismatch
+	)
+
+	switch {
+	case errors.As(err, &validation):
+		st := status.New(codes.InvalidArgument, validation.Msg)
+		if validation.Field != "" {
+			violation := &errdetails.BadRequest_FieldViolation{Field: validation.Field, Description: validation.Msg}
+			if d, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{violation}}); err == nil {
+				st = d
+			}
+		}
+		return st.Err()
+	case errors.As(err, &notFound):
+		return status.Error(codes.NotFound, notFound.Msg)
+	case errors.As(err, &conflict):
+		return status.Error(codes.AlreadyExists, conflict.Msg)
+	case errors.As(err, &unauthorized):
+		return status.Error(codes.Unauthenticated, unauthorized.Msg)
+	case errors.As(err, &quota):
+		return status.Error(codes.ResourceExhausted, quota.Msg)
+	case errors.As(err, &busy):
+		st := status.New(codes.Unavailable, busy.Msg)
+		if d, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(busy.RetryAfter)}); err == nil {
+			st = d
+		}
+		return st.Err()
+	case errors.As(err, &aborted):
+		return status.Error(codes.Aborted, aborted.Msg)
+	case errors.As(err, &mismatch):
+		return status.Error(codes.FailedPrecondition, mismatch.Msg)
+	}
+	log.Printf("Call failed: %v\n", err)
+	return status.Error(codes.Internal, "Internal error.")
+}
@@ -0,0 +1,546 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: gobuzz/v1/fetcher.proto
+
+package fetcherpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Fetcher_CreateFetch_FullMethodName    = "/gobuzz.v1.Fetcher/CreateFetch"
+	Fetcher_GetFetch_FullMethodName       = "/gobuzz.v1.Fetcher/GetFetch"
+	Fetcher_ListFetches_FullMethodName    = "/gobuzz.v1.Fetcher/ListFetches"
+	Fetcher_UpdateFetch_FullMethodName    = "/gobuzz.v1.Fetcher/UpdateFetch"
+	Fetcher_DeleteFetch_FullMethodName    = "/gobuzz.v1.Fetcher/DeleteFetch"
+	Fetcher_PauseFetch_FullMethodName     = "/gobuzz.v1.Fetcher/PauseFetch"
+	Fetcher_ResumeFetch_FullMethodName    = "/gobuzz.v1.Fetcher/ResumeFetch"
+	Fetcher_RunFetch_FullMethodName       = "/gobuzz.v1.Fetcher/RunFetch"
+	Fetcher_TryFetch_FullMethodName       = "/gobuzz.v1.Fetcher/TryFetch"
+	Fetcher_ListHistory_FullMethodName    = "/gobuzz.v1.Fetcher/ListHistory"
+	Fetcher_WatchResponses_FullMethodName = "/gobuzz.v1.Fetcher/WatchResponses"
+)
+
+// FetcherClient is the client API for Fetcher service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Fetcher manages fetches and reads their responses, the same as REST
+// API under /api/v1/fetcher. Calls are authenticated with API key sent
+// in x-api-key or authorization ("Bearer <key>") metadata and need
+// fetchers:read or fetchers:write scope. Fetches belong to tenant of the
+// key.
+type FetcherClient interface {
+	// CreateFetch creates fetch and starts its Gopher.
+	CreateFetch(ctx context.Context, in *CreateFetchRequest, opts ...grpc.CallOption) (*CreateFetchResponse, error)
+	// GetFetch returns stored fetch.
+	GetFetch(ctx context.Context, in *GetFetchRequest, opts ...grpc.CallOption) (*Fetch, error)
+	// ListFetches returns page of fetches sorted by creation.
+	ListFetches(ctx context.Context, in *ListFetchesRequest, opts ...grpc.CallOption) (*ListFetchesResponse, error)
+	// UpdateFetch replaces definition of fetch and restarts its Gopher.
+	UpdateFetch(ctx context.Context, in *UpdateFetchRequest, opts ...grpc.CallOption) (*Fetch, error)
+	// DeleteFetch removes fetch and stops its Gopher.
+	DeleteFetch(ctx context.Context, in *DeleteFetchRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// PauseFetch stops Gopher of fetch until it is resumed or pause ends.
+	PauseFetch(ctx context.Context, in *PauseFetchRequest, opts ...grpc.CallOption) (*Fetch, error)
+	// ResumeFetch runs Gopher of paused fetch again.
+	ResumeFetch(ctx context.Context, in *ResumeFetchRequest, opts ...grpc.CallOption) (*Fetch, error)
+	// RunFetch fetches URL of fetch at once and returns the response stored
+	// in history as manual one. Gopher of the fetch keeps its schedule.
+	RunFetch(ctx context.Context, in *RunFetchRequest, opts ...grpc.CallOption) (*Response, error)
+	// TryFetch validates fetch definition and fetches its URL once without
+	// storing anything or running a Gopher.
+	TryFetch(ctx context.Context, in *TryFetchRequest, opts ...grpc.CallOption) (*TryFetchResponse, error)
+	// ListHistory returns page of response history of fetch.
+	ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryResponse, error)
+	// WatchResponses streams responses of fetch as they are stored until
+	// the call is cancelled.
+	WatchResponses(ctx context.Context, in *WatchResponsesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Response], error)
+}
+
+type fetcherClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFetcherClient(cc grpc.ClientConnInterface) FetcherClient {
+	return &fetcherClient{cc}
+}
+
+func (c *fetcherClient) CreateFetch(ctx context.Context, in *CreateFetchRequest, opts ...grpc.CallOption) (*CreateFetchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFetchResponse)
+	err := c.cc.Invoke(ctx, Fetcher_CreateFetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) GetFetch(ctx context.Context, in *GetFetchRequest, opts ...grpc.CallOption) (*Fetch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Fetch)
+	err := c.cc.Invoke(ctx, Fetcher_GetFetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) ListFetches(ctx context.Context, in *ListFetchesRequest, opts ...grpc.CallOption) (*ListFetchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFetchesResponse)
+	err := c.cc.Invoke(ctx, Fetcher_ListFetches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) UpdateFetch(ctx context.Context, in *UpdateFetchRequest, opts ...grpc.CallOption) (*Fetch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Fetch)
+	err := c.cc.Invoke(ctx, Fetcher_UpdateFetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) DeleteFetch(ctx context.Context, in *DeleteFetchRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Fetcher_DeleteFetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) PauseFetch(ctx context.Context, in *PauseFetchRequest, opts ...grpc.CallOption) (*Fetch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Fetch)
+	err := c.cc.Invoke(ctx, Fetcher_PauseFetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) ResumeFetch(ctx context.Context, in *ResumeFetchRequest, opts ...grpc.CallOption) (*Fetch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Fetch)
+	err := c.cc.Invoke(ctx, Fetcher_ResumeFetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) RunFetch(ctx context.Context, in *RunFetchRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Fetcher_RunFetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) TryFetch(ctx context.Context, in *TryFetchRequest, opts ...grpc.CallOption) (*TryFetchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TryFetchResponse)
+	err := c.cc.Invoke(ctx, Fetcher_TryFetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHistoryResponse)
+	err := c.cc.Invoke(ctx, Fetcher_ListHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fetcherClient) WatchResponses(ctx context.Context, in *WatchResponsesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Response], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Fetcher_ServiceDesc.Streams[0], Fetcher_WatchResponses_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchResponsesRequest, Response]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Fetcher_WatchResponsesClient = grpc.ServerStreamingClient[Response]
+
+// FetcherServer is the server API for Fetcher service.
+// All implementations must embed UnimplementedFetcherServer
+// for forward compatibility.
+//
+// Fetcher manages fetches and reads their responses, the same as REST
+// API under /api/v1/fetcher. Calls are authenticated with API key sent
+// in x-api-key or authorization ("Bearer <key>") metadata and need
+// fetchers:read or fetchers:write scope. Fetches belong to tenant of the
+// key.
+type FetcherServer interface {
+	// CreateFetch creates fetch and starts its Gopher.
+	CreateFetch(context.Context, *CreateFetchRequest) (*CreateFetchResponse, error)
+	// GetFetch returns stored fetch.
+	GetFetch(context.Context, *GetFetchRequest) (*Fetch, error)
+	// ListFetches returns page of fetches sorted by creation.
+	ListFetches(context.Context, *ListFetchesRequest) (*ListFetchesResponse, error)
+	// UpdateFetch replaces definition of fetch and restarts its Gopher.
+	UpdateFetch(context.Context, *UpdateFetchRequest) (*Fetch, error)
+	// DeleteFetch removes fetch and stops its Gopher.
+	DeleteFetch(context.Context, *DeleteFetchRequest) (*emptypb.Empty, error)
+	// PauseFetch stops Gopher of fetch until it is resumed or pause ends.
+	PauseFetch(context.Context, *PauseFetchRequest) (*Fetch, error)
+	// ResumeFetch runs Gopher of paused fetch again.
+	ResumeFetch(context.Context, *ResumeFetchRequest) (*Fetch, error)
+	// RunFetch fetches URL of fetch at once and returns the response stored
+	// in history as manual one. Gopher of the fetch keeps its schedule.
+	RunFetch(context.Context, *RunFetchRequest) (*Response, error)
+	// TryFetch validates fetch definition and fetches its URL once without
+	// storing anything or running a Gopher.
+	TryFetch(context.Context, *TryFetchRequest) (*TryFetchResponse, error)
+	// ListHistory returns page of response history of fetch.
+	ListHistory(context.Context, *ListHistoryRequest) (*ListHistoryResponse, error)
+	// WatchResponses streams responses of fetch as they are stored until
+	// the call is cancelled.
+	WatchResponses(*WatchResponsesRequest, grpc.ServerStreamingServer[Response]) error
+	mustEmbedUnimplementedFetcherServer()
+}
+
+// UnimplementedFetcherServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFetcherServer struct{}
+
+func (UnimplementedFetcherServer) CreateFetch(context.Context, *CreateFetchRequest) (*CreateFetchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFetch not implemented")
+}
+func (UnimplementedFetcherServer) GetFetch(context.Context, *GetFetchRequest) (*Fetch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFetch not implemented")
+}
+func (UnimplementedFetcherServer) ListFetches(context.Context, *ListFetchesRequest) (*ListFetchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFetches not implemented")
+}
+func (UnimplementedFetcherServer) UpdateFetch(context.Context, *UpdateFetchRequest) (*Fetch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFetch not implemented")
+}
+func (UnimplementedFetcherServer) DeleteFetch(context.Context, *DeleteFetchRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFetch not implemented")
+}
+func (UnimplementedFetcherServer) PauseFetch(context.Context, *PauseFetchRequest) (*Fetch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseFetch not implemented")
+}
+func (UnimplementedFetcherServer) ResumeFetch(context.Context, *ResumeFetchRequest) (*Fetch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeFetch not implemented")
+}
+func (UnimplementedFetcherServer) RunFetch(context.Context, *RunFetchRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunFetch not implemented")
+}
+func (UnimplementedFetcherServer) TryFetch(context.Context, *TryFetchRequest) (*TryFetchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TryFetch not implemented")
+}
+func (UnimplementedFetcherServer) ListHistory(context.Context, *ListHistoryRequest) (*ListHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHistory not implemented")
+}
+func (UnimplementedFetcherServer) WatchResponses(*WatchResponsesRequest, grpc.ServerStreamingServer[Response]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchResponses not implemented")
+}
+func (UnimplementedFetcherServer) mustEmbedUnimplementedFetcherServer() {}
+func (UnimplementedFetcherServer) testEmbeddedByValue()                 {}
+
+// UnsafeFetcherServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FetcherServer will
+// result in compilation errors.
+type UnsafeFetcherServer interface {
+	mustEmbedUnimplementedFetcherServer()
+}
+
+func RegisterFetcherServer(s grpc.ServiceRegistrar, srv FetcherServer) {
+	// If the following call pancis, it indicates UnimplementedFetcherServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Fetcher_ServiceDesc, srv)
+}
+
+func _Fetcher_CreateFetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).CreateFetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_CreateFetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).CreateFetch(ctx, req.(*CreateFetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_GetFetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).GetFetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_GetFetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).GetFetch(ctx, req.(*GetFetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_ListFetches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFetchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).ListFetches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_ListFetches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).ListFetches(ctx, req.(*ListFetchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_UpdateFetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).UpdateFetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_UpdateFetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).UpdateFetch(ctx, req.(*UpdateFetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_DeleteFetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).DeleteFetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_DeleteFetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).DeleteFetch(ctx, req.(*DeleteFetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_PauseFetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseFetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).PauseFetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_PauseFetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).PauseFetch(ctx, req.(*PauseFetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_ResumeFetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeFetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).ResumeFetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_ResumeFetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).ResumeFetch(ctx, req.(*ResumeFetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_RunFetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunFetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).RunFetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_RunFetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).RunFetch(ctx, req.(*RunFetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_TryFetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TryFetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).TryFetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_TryFetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).TryFetch(ctx, req.(*TryFetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_ListHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FetcherServer).ListHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fetcher_ListHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FetcherServer).ListHistory(ctx, req.(*ListHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fetcher_WatchResponses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchResponsesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FetcherServer).WatchResponses(m, &grpc.GenericServerStream[WatchResponsesRequest, Response]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Fetcher_WatchResponsesServer = grpc.ServerStreamingServer[Response]
+
+// Fetcher_ServiceDesc is the grpc.ServiceDesc for Fetcher service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Fetcher_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gobuzz.v1.Fetcher",
+	HandlerType: (*FetcherServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFetch",
+			Handler:    _Fetcher_CreateFetch_Handler,
+		},
+		{
+			MethodName: "GetFetch",
+			Handler:    _Fetcher_GetFetch_Handler,
+		},
+		{
+			MethodName: "ListFetches",
+			Handler:    _Fetcher_ListFetches_Handler,
+		},
+		{
+			MethodName: "UpdateFetch",
+			Handler:    _Fetcher_UpdateFetch_Handler,
+		},
+		{
+			MethodName: "DeleteFetch",
+			Handler:    _Fetcher_DeleteFetch_Handler,
+		},
+		{
+			MethodName: "PauseFetch",
+			Handler:    _Fetcher_PauseFetch_Handler,
+		},
+		{
+			MethodName: "ResumeFetch",
+			Handler:    _Fetcher_ResumeFetch_Handler,
+		},
+		{
+			MethodName: "RunFetch",
+			Handler:    _Fetcher_RunFetch_Handler,
+		},
+		{
+			MethodName: "TryFetch",
+			Handler:    _Fetcher_TryFetch_Handler,
+		},
+		{
+			MethodName: "ListHistory",
+			Handler:    _Fetcher_ListHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchResponses",
+			Handler:       _Fetcher_WatchResponses_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gobuzz/v1/fetcher.proto",
+}
@@ -0,0 +1,276 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/http/rpc/fetcherpb"
+	"github.com/gobuzz/pkg/http/worker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateFetch creates fetch of the caller tenant and runs its Gopher.
+func (s *server) CreateFetch(ctx context.Context, req *fetcherpb.CreateFetchRequest) (*fetcherpb.CreateFetchResponse, error) {
+	if req.Fetch == nil {
+		return nil, statusOf(failure.Invalid("fetch", "Fetch definition is required."))
+	}
+
+	f := fetchOf(tenantOf(ctx), req.Fetch)
+	var (
+		id      string
+		created = true
+		err     error
+	)
+	if req.Dedup {
+		id, created, err = s.adder.FindOrCreateRecord(f)
+	} else {
+		id, err = s.adder.CreateRecord(f)
+	}
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	switch {
+	case created && req.RunNow:
+		s.workers.StartNow(id, f)
+	case created:
+		s.workers.Start(id, f)
+	}
+	return &fetcherpb.CreateFetchResponse{Id: id, Existing: !created}, nil
+}
+
+// GetFetch returns a single fetch of the caller tenant.
+func (s *server) GetFetch(ctx context.Context, req *fetcherpb.GetFetchRequest) (*fetcherpb.Fetch, error) {
+	tenant := tenantOf(ctx)
+	id, err := s.lister.Resolve(tenant, req.Id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	f, err := s.lister.Fetch(tenant, id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return fetchPB(f), nil
+}
+
+// ListFetches returns page of fetches of the caller tenant.
+func (s *server) ListFetches(ctx context.Context, req *fetcherpb.ListFetchesRequest) (*fetcherpb.ListFetchesResponse, error) {
+	q := listing.FetchQuery{
+		Page: listing.Page{Limit: int(req.Limit), Cursor: req.Cursor, Desc: req.Desc},
+		From: timeOf(req.From),
+		To:   timeOf(req.To),
+		URL:  req.Url,
+		Tag:  req.Tag,
+	}
+	fetches, next, err := s.lister.Fetches(tenantOf(ctx), q)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	res := &fetcherpb.ListFetchesResponse{Fetches: make([]*fetcherpb.Fetch, len(fetches)), NextCursor: next}
+	for i, f := range fetches {
+		res.Fetches[i] = fetchPB(f)
+	}
+	return res, nil
+}
+
+// UpdateFetch replaces definition of fetch of the caller tenant,
+// restarts its Gopher and returns the stored fetch.
+func (s *server) UpdateFetch(ctx context.Context, req *fetcherpb.UpdateFetchRequest) (*fetcherpb.Fetch, error) {
+	if req.Fetch == nil {
+		return nil, statusOf(failure.Invalid("fetch", "Fetch definition is required."))
+	}
+
+	tenant := tenantOf(ctx)
+	id, err := s.lister.Resolve(tenant, req.Id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	f := fetchOf(tenant, req.Fetch)
+	if err := s.adder.UpdateRecord(tenant, id, f); err != nil {
+		return nil, statusOf(err)
+	}
+	s.workers.Start(id, f)
+
+	stored, err := s.lister.Fetch(tenant, id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return fetchPB(stored), nil
+}
+
+// DeleteFetch removes fetch of the caller tenant and stops its Gopher.
+func (s *server) DeleteFetch(ctx context.Context, req *fetcherpb.DeleteFetchRequest) (*emptypb.Empty, error) {
+	tenant := tenantOf(ctx)
+	id, err := s.lister.Resolve(tenant, req.Id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	if err := s.adder.DeleteRecord(tenant, id); err != nil {
+		return nil, statusOf(err)
+	}
+	s.workers.Stop(tenant, id)
+	return &emptypb.Empty{}, nil
+}
+
+// PauseFetch stops Gopher of a single fetch of the caller tenant until
+// it is resumed or until the pause end.
+func (s *server) PauseFetch(ctx context.Context, req *fetcherpb.PauseFetchRequest) (*fetcherpb.Fetch, error) {
+	tenant := tenantOf(ctx)
+	id, err := s.lister.Resolve(tenant, req.Id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	until := timeOf(req.Until)
+	if err := s.adder.PauseRecord(tenant, id, until); err != nil {
+		return nil, statusOf(err)
+	}
+	f, err := s.lister.Fetch(tenant, id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	s.workers.Pause(id, f.Definition(tenant), until)
+	return fetchPB(f), nil
+}
+
+// ResumeFetch runs Gopher of a paused fetch of the caller tenant again.
+func (s *server) ResumeFetch(ctx context.Context, req *fetcherpb.ResumeFetchRequest) (*fetcherpb.Fetch, error) {
+	tenant := tenantOf(ctx)
+	id, err := s.lister.Resolve(tenant, req.Id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	if err := s.adder.ResumeRecord(tenant, id); err != nil {
+		return nil, statusOf(err)
+	}
+	f, err := s.lister.Fetch(tenant, id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	if !s.workers.Resume(id, f.Definition(tenant)) { // stored pause only
+		s.workers.Start(id, f.Definition(tenant))
+	}
+	return fetchPB(f), nil
+}
+
+// RunFetch fetches a single fetch of the caller tenant at once and
+// returns its response, waiting for it until worker.RunTimeout at most.
+func (s *server) RunFetch(ctx context.Context, req *fetcherpb.RunFetchRequest) (*fetcherpb.Response, error) {
+	tenant := tenantOf(ctx)
+	id, err := s.lister.Resolve(tenant, req.Id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	f, err := s.lister.Fetch(tenant, id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, worker.RunTimeout)
+	defer cancel()
+	res := s.workers.Run(ctx, id, f.Definition(tenant))
+	switch {
+	case res.Status == http.StatusGatewayTimeout:
+		return nil, status.Error(codes.DeadlineExceeded, res.Msg)
+	case res.Response == nil:
+		return nil, status.Error(codes.Unavailable, res.Msg)
+	}
+	return responsePB(listing.ResponseOf(*res.Response, time.Now())), nil
+}
+
+// TryFetch validates fetch definition of the caller tenant and fetches
+// it once, waiting for it until worker.RunTimeout at most. Nothing is
+// stored.
+func (s *server) TryFetch(ctx context.Context, req *fetcherpb.TryFetchRequest) (*fetcherpb.TryFetchResponse, error) {
+	if req.Fetch == nil {
+		return nil, statusOf(failure.Invalid("fetch", "Fetch definition is required."))
+	}
+	f := fetchOf(tenantOf(ctx), req.Fetch)
+	if err := s.adder.Validate(f); err != nil {
+		return nil, statusOf(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, worker.RunTimeout)
+	defer cancel()
+	res := s.workers.Try(ctx, f)
+	if res.Status == http.StatusGatewayTimeout {
+		return nil, status.Error(codes.DeadlineExceeded, res.Msg)
+	}
+
+	m := &fetcherpb.TryFetchResponse{Status: int32(res.Code), Headers: make(map[string]string, len(res.Header))}
+	for name, values := range res.Header {
+		m.Headers[name] = strings.Join(values, ", ")
+	}
+	if res.Response == nil { // upstream status is not expected
+		m.Response = &fetcherpb.Response{Outcome: listing.OutcomeError, Error: res.Msg}
+		return m, nil
+	}
+	rec := *res.Response
+	if rec.Err == "" {
+		m.Truncated = worker.Truncate(&rec, worker.MaxTryBody)
+	}
+	m.Response = responsePB(listing.ResponseOf(rec, time.Now()))
+	return m, nil
+}
+
+// fetchOf returns tenant fetch defined by request message.
+func fetchOf(tenant string, d *fetcherpb.FetchDefinition) adding.Fetch {
+	f := adding.Fetch{
+		Tenant:   tenant,
+		Name:     d.Name,
+		URL:      d.Url,
+		Interval: int(d.Interval),
+		Tags:     d.Tags,
+	}
+	for _, c := range d.Assertions {
+		f.Assertions = append(f.Assertions, adding.Check{Type: c.Type, Path: c.Path, Value: c.Value, Latency: c.Latency})
+	}
+	for _, e := range d.Extractors {
+		f.Extractors = append(f.Extractors, adding.Extractor{Name: e.Name, Source: e.Source, Expr: e.Expr, Group: int(e.Group), Type: e.Type})
+	}
+	if r := d.Retention; r != nil {
+		f.Retention = &adding.Retention{MaxRecords: int(r.MaxRecords), MaxAge: int(r.MaxAge), MaxBytes: int(r.MaxBytes)}
+	}
+	return f
+}
+
+// fetchPB returns message of stored fetch.
+func fetchPB(f listing.Fetch) *fetcherpb.Fetch {
+	m := &fetcherpb.Fetch{
+		Id:        f.ID,
+		Name:      f.Name,
+		Url:       f.URL,
+		Interval:  int32(f.Interval),
+		Tags:      f.Tags,
+		Paused:    f.Paused,
+		CreatedAt: timestamppb.New(f.CreatedAt),
+	}
+	if f.PausedUntil != nil {
+		m.PausedUntil = timestamppb.New(*f.PausedUntil)
+	}
+	for _, c := range f.Assertions {
+		m.Assertions = append(m.Assertions, &fetcherpb.Check{Type: c.Type, Path: c.Path, Value: c.Value, Latency: c.Latency})
+	}
+	for _, e := range f.Extractors {
+		m.Extractors = append(m.Extractors, &fetcherpb.Extractor{Name: e.Name, Source: e.Source, Expr: e.Expr, Group: int32(e.Group), Type: e.Type})
+	}
+	if r := f.Retention; r != nil {
+		m.Retention = &fetcherpb.Retention{MaxRecords: int64(r.MaxRecords), MaxAge: int64(r.MaxAge), MaxBytes: int64(r.MaxBytes)}
+	}
+	return m
+}
+
+// timeOf returns time of timestamp, zero time if it is not set.
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,133 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/http/rpc/fetcherpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListHistory returns page of response history of fetch of the caller
+// tenant.
+func (s *server) ListHistory(ctx context.Context, req *fetcherpb.ListHistoryRequest) (*fetcherpb.ListHistoryResponse, error) {
+	q := listing.HistoryQuery{
+		Page:    listing.Page{Limit: int(req.Limit), Cursor: req.Cursor, Desc: req.Desc},
+		From:    timeOf(req.From),
+		To:      timeOf(req.To),
+		Outcome: req.Outcome,
+		Sort:    req.Sort,
+	}
+	tenant := tenantOf(ctx)
+	id, err := s.lister.Resolve(tenant, req.Id)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	history, next, err := s.lister.History(tenant, id, q)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	res := &fetcherpb.ListHistoryResponse{Responses: make([]*fetcherpb.Response, len(history)), NextCursor: next}
+	for i, r := range history {
+		res.Responses[i] = responsePB(r)
+	}
+	return res, nil
+}
+
+// WatchResponses sends responses of fetch of the caller tenant as they
+// are stored, looking for new ones every watch interval. The last
+// req.Last responses stored before the call are sent first, none for
+// fetch which has not run yet.
+func (s *server) WatchResponses(req *fetcherpb.WatchResponsesRequest, stream fetcherpb.Fetcher_WatchResponsesServer) error {
+	ctx := stream.Context()
+	tenant := tenantOf(ctx)
+	id, err := s.lister.Resolve(tenant, req.Id)
+	if err != nil {
+		return statusOf(err)
+	}
+
+	// Newest responses come first, they are sent oldest first. Unless
+	// some are requested, the newest one only marks where to start.
+	limit := int(req.Last)
+	if limit <= 0 {
+		limit = 1
+	}
+	last, _, err := s.lister.History(tenant, id, listing.HistoryQuery{Page: listing.Page{Limit: limit, Desc: true}})
+	if err != nil {
+		return statusOf(err)
+	}
+	seq, since := -1, time.Time{} // of the newest response sent
+	if len(last) > 0 {
+		seq, since = last[0].Seq, unixTime(last[0].CreatedAt)
+	}
+	if req.Last <= 0 {
+		last = nil
+	}
+	for i := len(last) - 1; i >= 0; i-- {
+		if err := stream.Send(responsePB(last[i])); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(s.every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		// Time range is inclusive, responses already sent are skipped by
+		// their sequence.
+		q := listing.HistoryQuery{Page: listing.Page{Limit: listing.MaxLimit}, From: since}
+		for {
+			page, next, err := s.lister.History(tenant, id, q)
+			if err != nil {
+				return statusOf(err)
+			}
+			for _, r := range page {
+				if r.Seq <= seq {
+					continue
+				}
+				if err := stream.Send(responsePB(r)); err != nil {
+					return err
+				}
+				seq, since = r.Seq, unixTime(r.CreatedAt)
+			}
+			if next == "" {
+				break
+			}
+			q.Cursor = next
+		}
+	}
+}
+
+// responsePB returns message of response history record.
+func responsePB(r listing.Response) *fetcherpb.Response {
+	m := &fetcherpb.Response{
+		MediaType:    r.MediaType,
+		Duration:     r.Duration,
+		LimiterDelay: r.Delay,
+		CreatedAt:    timestamppb.New(unixTime(r.CreatedAt)),
+		Outcome:      r.Outcome,
+		Error:        r.Err,
+		Manual:       r.Manual,
+	}
+	if r.Data != nil {
+		m.Body = &fetcherpb.Response_Data{Data: r.Data}
+	} else {
+		m.Body = &fetcherpb.Response_Text{Text: r.Content}
+	}
+	for _, a := range r.Assertions {
+		m.Assertions = append(m.Assertions, &fetcherpb.CheckResult{Type: a.Type, Passed: a.Passed, Msg: a.Msg})
+	}
+	return m
+}
+
+// unixTime converts Unix seconds into time.
+func unixTime(sec float64) time.Time {
+	return time.Unix(0, int64(sec*float64(time.Second)))
+}
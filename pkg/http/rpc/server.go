@@ -0,0 +1,65 @@
+// Package rpc serves gRPC API of fetches defined in
+// proto/gobuzz/v1/fetcher.proto, the same operations as REST API.
+package rpc
+
+//go:generate protoc -I ../../../proto --go_out=../../.. --go_opt=module=github.com/gobuzz --go-grpc_out=../../.. --go-grpc_opt=module=github.com/gobuzz gobuzz/v1/fetcher.proto
+
+import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+	"github.com/gobuzz/pkg/http/rpc/fetcherpb"
+	"github.com/gobuzz/pkg/http/worker"
+	"google.golang.org/grpc"
+)
+
+// DefaultWatchInterval is how often WatchResponses looks for new
+// responses by default.
+const DefaultWatchInterval = time.Second
+
+// Services aggregates domain services used by gRPC server.
+type Services struct {
+	Adder         adding.Service
+	Responder     responding.Service
+	Lister        listing.Service
+	Auth          authenticating.Service
+	Hosts         *worker.HostLimiter // outbound limits shared by Gophers
+	Workers       *worker.Pool        // runs Gophers, made of Responder and Hosts if nil
+	WatchInterval time.Duration       // DefaultWatchInterval if zero
+}
+
+// server implements fetcherpb.FetcherServer with domain services.
+type server struct {
+	fetcherpb.UnimplementedFetcherServer
+	adder   adding.Service
+	lister  listing.Service
+	workers *worker.Pool
+	every   time.Duration
+}
+
+// NewServer creates gRPC server with registered Fetcher service. Calls
+// are authenticated with API keys of svc.Auth.
+func NewServer(svc Services, opts ...grpc.ServerOption) *grpc.Server {
+	if svc.Workers == nil {
+		svc.Workers = worker.NewPool(svc.Responder, svc.Hosts, nil)
+	}
+	if svc.WatchInterval <= 0 {
+		svc.WatchInterval = DefaultWatchInterval
+	}
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryAuth(svc.Auth)),
+		grpc.ChainStreamInterceptor(streamAuth(svc.Auth)),
+	)
+	srv := grpc.NewServer(opts...)
+	fetcherpb.RegisterFetcherServer(srv, &server{
+		adder:   svc.Adder,
+		lister:  svc.Lister,
+		workers: svc.Workers,
+		every:   svc.WatchInterval,
+	})
+	return srv
+}
@@ -0,0 +1,312 @@
+package rpc_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/authenticating"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+	. "github.com/gobuzz/pkg/http/rpc"
+	"github.com/gobuzz/pkg/http/rpc/fetcherpb"
+	"github.com/gobuzz/pkg/http/worker"
+	"github.com/gobuzz/pkg/storage/memory"
+	"github.com/gobuzz/pkg/ulid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// upstream answers fetches instead of the network.
+type upstream func(r *http.Request) (*http.Response, error)
+
+func (u upstream) RoundTrip(r *http.Request) (*http.Response, error) {
+	return u(r)
+}
+
+var _ = Describe("The gRPC server", func() {
+	var (
+		srv    *grpc.Server
+		conn   *grpc.ClientConn
+		client fetcherpb.FetcherClient
+		respsr responding.Service
+		auth   authenticating.Service
+		ctx    context.Context
+	)
+
+	BeforeEach(func() { // Configuration
+		s := new(memory.ResponseFetch)
+		auth = authenticating.NewService(&s.Keys)
+		_, secret, _ := auth.CreateKey("ci", "", []string{authenticating.ScopeAdmin})
+		respsr = responding.NewService(&s.Responses)
+		fetched := upstream(func(r *http.Request) (*http.Response, error) {
+			header := http.Header{"Age": {"7"}}
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader("abc")), Request: r}, nil
+		})
+		srv = NewServer(Services{ // Creation
+			Adder:         adding.NewService(&s.Fetches, &s.Tenants),
+			Responder:     respsr,
+			Workers:       worker.NewPool(respsr, nil, &http.Client{Transport: fetched}),
+			Lister:        listing.NewService(&s.Responses, &s.Fetches),
+			Auth:          auth,
+			WatchInterval: 10 * time.Millisecond,
+		})
+
+		lis := bufconn.Listen(1 << 20)
+		go srv.Serve(lis)
+		var err error
+		conn, err = grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		client = fetcherpb.NewFetcherClient(conn)
+		ctx = metadata.AppendToOutgoingContext(context.Background(), "x-api-key", secret)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		srv.Stop()
+	})
+
+	definition := &fetcherpb.FetchDefinition{
+		Name:       "front",
+		Url:        "https://httpbin.org/range/15",
+		Interval:   3600,
+		Assertions: []*fetcherpb.Check{{Type: adding.CheckContains, Value: "abc"}},
+		Retention:  &fetcherpb.Retention{MaxRecords: 10},
+		Tags:       []string{"prod"},
+	}
+
+	Describe("When managing fetches", func() {
+		It("Should create, get, list, update and delete them.", func() {
+			created, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: definition})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ulid.Valid(created.Id)).To(BeTrue())
+
+			f, err := client.GetFetch(ctx, &fetcherpb.GetFetchRequest{Id: "front"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Id).To(Equal(created.Id))
+			Expect(f.Name).To(Equal("front"))
+			Expect(f.Assertions[0].Value).To(Equal("abc"))
+			Expect(f.Retention.MaxRecords).To(Equal(int64(10)))
+			Expect(f.CreatedAt.AsTime()).To(BeTemporally("~", time.Now(), time.Minute))
+
+			second, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: &fetcherpb.FetchDefinition{Url: "https://httpbin.org/range/16", Interval: 60}})
+			Expect(err).NotTo(HaveOccurred())
+			page, err := client.ListFetches(ctx, &fetcherpb.ListFetchesRequest{Limit: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Fetches).To(HaveLen(1))
+			page, err = client.ListFetches(ctx, &fetcherpb.ListFetchesRequest{Limit: 1, Cursor: page.NextCursor})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Fetches[0].Id).To(Equal(second.Id))
+			Expect(page.NextCursor).To(BeEmpty())
+
+			f, err = client.UpdateFetch(ctx, &fetcherpb.UpdateFetchRequest{Id: created.Id, Fetch: &fetcherpb.FetchDefinition{Name: "front", Url: definition.Url, Interval: 60}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Interval).To(Equal(int32(60)))
+			Expect(f.Tags).To(BeEmpty())
+
+			_, err = client.DeleteFetch(ctx, &fetcherpb.DeleteFetchRequest{Id: created.Id})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.GetFetch(ctx, &fetcherpb.GetFetchRequest{Id: created.Id})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+
+		It("Should pause and resume fetch.", func() {
+			created, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: definition})
+			Expect(err).NotTo(HaveOccurred())
+
+			until := time.Now().Add(time.Hour)
+			f, err := client.PauseFetch(ctx, &fetcherpb.PauseFetchRequest{Id: "front", Until: timestamppb.New(until)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Paused).To(BeTrue())
+			Expect(f.PausedUntil.AsTime()).To(BeTemporally("==", until))
+
+			f, err = client.ResumeFetch(ctx, &fetcherpb.ResumeFetchRequest{Id: created.Id})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Paused).To(BeFalse())
+			Expect(f.PausedUntil).To(BeNil())
+
+			_, err = client.ResumeFetch(ctx, &fetcherpb.ResumeFetchRequest{Id: created.Id})
+			Expect(status.Code(err)).To(Equal(codes.AlreadyExists))
+
+			_, err = client.PauseFetch(ctx, &fetcherpb.PauseFetchRequest{Id: "front", Until: timestamppb.New(time.Now().Add(-time.Hour))})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("Should run fetch at once and store manual response.", func() {
+			_, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: definition})
+			Expect(err).NotTo(HaveOccurred())
+
+			r, err := client.RunFetch(ctx, &fetcherpb.RunFetchRequest{Id: "front"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.GetText()).To(Equal("abc"))
+			Expect(r.Outcome).To(Equal(listing.OutcomeOK))
+			Expect(r.Manual).To(BeTrue())
+
+			page, err := client.ListHistory(ctx, &fetcherpb.ListHistoryRequest{Id: "front"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Responses).To(HaveLen(1))
+			Expect(page.Responses[0].Manual).To(BeTrue())
+
+			_, err = client.RunFetch(ctx, &fetcherpb.RunFetchRequest{Id: "missing"})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+
+		It("Should try fetch without storing it.", func() {
+			tried, err := client.TryFetch(ctx, &fetcherpb.TryFetchRequest{Fetch: definition})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tried.Status).To(Equal(int32(http.StatusOK)))
+			Expect(tried.Headers).To(HaveKeyWithValue("Age", "7"))
+			Expect(tried.Response.GetText()).To(Equal("abc"))
+			Expect(tried.Response.Outcome).To(Equal(listing.OutcomeOK))
+			Expect(tried.Truncated).To(BeFalse())
+
+			page, err := client.ListFetches(ctx, &fetcherpb.ListFetchesRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Fetches).To(BeEmpty())
+
+			_, err = client.TryFetch(ctx, &fetcherpb.TryFetchRequest{Fetch: &fetcherpb.FetchDefinition{Url: "woops", Interval: 60}})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("Should return fetch of the same URL in dedup mode.", func() {
+			created, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: definition})
+			Expect(err).NotTo(HaveOccurred())
+
+			same, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: &fetcherpb.FetchDefinition{Url: definition.Url, Interval: 60}, Dedup: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(same.Id).To(Equal(created.Id))
+			Expect(same.Existing).To(BeTrue())
+
+			other, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: &fetcherpb.FetchDefinition{Url: "https://httpbin.org/range/16", Interval: 60}, Dedup: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(other.Id).NotTo(Equal(created.Id))
+			Expect(other.Existing).To(BeFalse())
+		})
+
+		It("Should map domain errors into status codes.", func() {
+			_, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: &fetcherpb.FetchDefinition{Url: "woops", Interval: 60}})
+			st := status.Convert(err)
+			Expect(st.Code()).To(Equal(codes.InvalidArgument))
+			Expect(st.Message()).To(Equal("URL path is not accepted."))
+			Expect(st.Details()).To(HaveLen(1))
+			Expect(st.Details()[0].(*errdetails.BadRequest).FieldViolations[0].Field).To(Equal("url"))
+
+			_, err = client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: definition})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: definition})
+			Expect(status.Code(err)).To(Equal(codes.AlreadyExists))
+		})
+	})
+
+	Describe("When authenticating calls", func() {
+		It("Should require valid API key with matching scope.", func() {
+			_, err := client.GetFetch(context.Background(), &fetcherpb.GetFetchRequest{Id: "front"})
+			Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+
+			_, secret, err := auth.CreateKey("reader", "team-a", []string{authenticating.ScopeFetchersRead})
+			Expect(err).NotTo(HaveOccurred())
+			reader := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+secret)
+
+			_, err = client.CreateFetch(reader, &fetcherpb.CreateFetchRequest{Fetch: definition})
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+			_, err = client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: definition})
+			Expect(err).NotTo(HaveOccurred())
+			page, err := client.ListFetches(reader, &fetcherpb.ListFetchesRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Fetches).To(BeEmpty()) // other tenant
+		})
+	})
+
+	Describe("When reading responses", func() {
+		var id string
+
+		BeforeEach(func() {
+			created, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: definition})
+			Expect(err).NotTo(HaveOccurred())
+			id = created.Id
+			respsr.CreateRecord(responding.Response{StorageKeyID: id, Tenant: adding.DefaultTenant, Content: "abc", Duration: 0.1})
+			respsr.CreateRecord(responding.Response{StorageKeyID: id, Tenant: adding.DefaultTenant, Data: []byte{0, 1}, Duration: 0.2})
+		})
+
+		It("Should return history page.", func() {
+			res, err := client.ListHistory(ctx, &fetcherpb.ListHistoryRequest{Id: id, Desc: true, Limit: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Responses).To(HaveLen(1))
+			Expect(res.Responses[0].GetData()).To(Equal([]byte{0, 1}))
+			Expect(res.NextCursor).NotTo(BeEmpty())
+
+			res, err = client.ListHistory(ctx, &fetcherpb.ListHistoryRequest{Id: "front", Desc: true, Cursor: res.NextCursor})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Responses[0].GetText()).To(Equal("abc"))
+			Expect(res.Responses[0].Outcome).To(Equal(listing.OutcomeOK))
+
+			_, err = client.ListHistory(ctx, &fetcherpb.ListHistoryRequest{Id: id, Sort: "woops"})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("Should stream new responses until cancelled.", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			stream, err := client.WatchResponses(ctx, &fetcherpb.WatchResponsesRequest{Id: id, Last: 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			r, err := stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.GetData()).To(Equal([]byte{0, 1}))
+
+			respsr.CreateRecord(responding.Response{StorageKeyID: id, Tenant: adding.DefaultTenant, Content: "def", Duration: 0.3})
+			r, err = stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.GetText()).To(Equal("def"))
+
+			cancel()
+			_, err = stream.Recv()
+			Expect(status.Code(err)).To(Equal(codes.Canceled))
+		})
+
+		It("Should stream responses of fetch which has not run yet.", func() {
+			created, err := client.CreateFetch(ctx, &fetcherpb.CreateFetchRequest{Fetch: &fetcherpb.FetchDefinition{Url: "https://httpbin.org/range/20", Interval: 3600}})
+			Expect(err).NotTo(HaveOccurred())
+
+			res, err := client.ListHistory(ctx, &fetcherpb.ListHistoryRequest{Id: created.Id})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Responses).To(BeEmpty())
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			stream, err := client.WatchResponses(ctx, &fetcherpb.WatchResponsesRequest{Id: created.Id, Last: 5})
+			Expect(err).NotTo(HaveOccurred())
+
+			respsr.CreateRecord(responding.Response{StorageKeyID: created.Id, Tenant: adding.DefaultTenant, Content: "first", Duration: 0.1})
+			r, err := stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.GetText()).To(Equal("first"))
+		})
+
+		It("Should report missing fetch.", func() {
+			stream, err := client.WatchResponses(ctx, &fetcherpb.WatchResponsesRequest{Id: "missing"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = stream.Recv()
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+})
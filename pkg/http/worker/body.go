@@ -0,0 +1,147 @@
+package worker
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gobuzz/pkg/domain/responding"
+	"golang.org/x/net/html/charset"
+)
+
+// acceptEncoding is sent with each fetch request. Transparent gzip
+// decoding of http.Transport is disabled when it is set.
+const acceptEncoding = "gzip, deflate, br"
+
+// Body represents fetched response body decoded from its
+// Content-Encoding. Text bodies are converted to UTF-8.
+type Body struct {
+	Data      []byte
+	MediaType string
+	Binary    bool
+}
+
+// ReadBody reads up to limit decoded bytes of response body. Body is
+// decompressed according to Content-Encoding header and converted to
+// UTF-8 according to charset if its media type is textual. Text cut at
+// limit loses its trailing partial rune.
+func ReadBody(res *http.Response, limit int64) (Body, error) {
+	reader, err := decodeReader(res.Header.Get("Content-Encoding"), res.Body)
+	if err != nil {
+		return Body{}, err
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(reader, limit))
+	if err != nil {
+		return Body{}, err
+	}
+	cut := int64(len(data)) == limit
+
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "application/octet-stream"
+	}
+
+	if !isText(mediaType) {
+		return Body{Data: data, MediaType: mediaType, Binary: true}, nil
+	}
+
+	enc, name, _ := charset.DetermineEncoding(data, contentType)
+	if name != "utf-8" {
+		if data, err = enc.NewDecoder().Bytes(data); err != nil {
+			return Body{}, fmt.Errorf("decoding %s charset: %w", name, err)
+		}
+	}
+	if cut {
+		data = trimPartialRune(data)
+	}
+	if !utf8.Valid(data) {
+		return Body{Data: data, MediaType: mediaType, Binary: true}, nil
+	}
+	return Body{Data: data, MediaType: mediaType}, nil
+}
+
+// trimPartialRune drops UTF-8 sequence cut off at the end of data.
+func trimPartialRune(data []byte) []byte {
+	if r, size := utf8.DecodeLastRune(data); r != utf8.RuneError || size != 1 {
+		return data
+	}
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		if start := len(data) - i; utf8.RuneStart(data[start]) {
+			if !utf8.FullRune(data[start:]) {
+				return data[:start]
+			}
+			break
+		}
+	}
+	return data
+}
+
+// decodeReader returns reader decompressing body with encoding.
+func decodeReader(encoding string, body io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(body)
+	case "deflate":
+		// Most servers send zlib wrapped deflate, some send raw one.
+		// Only the two byte zlib header is buffered to tell them apart.
+		br := bufio.NewReader(body)
+		if h, err := br.Peek(2); err == nil && h[0]&0x0f == 8 && (uint16(h[0])<<8|uint16(h[1]))%31 == 0 {
+			return zlib.NewReader(br)
+		}
+		return flate.NewReader(br), nil
+	case "br":
+		return brotli.NewReader(body), nil
+	}
+	return nil, fmt.Errorf("unsupported Content-Encoding %q", encoding)
+}
+
+// isText reports whether media type holds textual content.
+func isText(mediaType string) bool {
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript",
+		"application/x-www-form-urlencoded", "application/yaml":
+		return true
+	}
+	return false
+}
+
+// Truncate cuts body of response r to limit bytes and reports whether it
+// was longer. Text body is cut at rune start, so that it stays valid
+// UTF-8.
+func Truncate(r *responding.Response, limit int) bool {
+	if len(r.Data) > limit {
+		r.Data = r.Data[:limit]
+		return true
+	}
+	if len(r.Content) <= limit {
+		return false
+	}
+	n := limit
+	for n > 0 && !utf8.RuneStart(r.Content[n]) {
+		n--
+	}
+	r.Content = r.Content[:n]
+	return true
+}
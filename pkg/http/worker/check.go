@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/responding"
+	"github.com/gobuzz/pkg/jsonpath"
+)
+
+// EvaluateChecks checks fetched content and elapsed fetch time
+// against each of the assertions. Result is returned for every
+// assertion in the same order as they were defined.
+func EvaluateChecks(assertions []adding.Check, content string, elapsed float64) []responding.CheckResult {
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	results := make([]responding.CheckResult, 0, len(assertions))
+	for _, a := range assertions {
+		passed, msg := evaluate(a, content, elapsed)
+		results = append(results, responding.CheckResult{Type: a.Type, Passed: passed, Msg: msg})
+	}
+	return results
+}
+
+// evaluate reports whether single assertion passed. If not, returns
+// message describing the reason.
+func evaluate(a adding.Check, content string, elapsed float64) (bool, string) {
+	switch a.Type {
+	case adding.CheckContains:
+		if !strings.Contains(content, a.Value) {
+			return false, fmt.Sprintf("content does not contain %q", a.Value)
+		}
+	case adding.CheckNotContains:
+		if strings.Contains(content, a.Value) {
+			return false, fmt.Sprintf("content contains %q", a.Value)
+		}
+	case adding.CheckRegex:
+		re, err := regexp.Compile(a.Value)
+		if err != nil {
+			return false, err.Error()
+		}
+		if !re.MatchString(content) {
+			return false, fmt.Sprintf("content does not match %q", a.Value)
+		}
+	case adding.CheckJSONPathEq, adding.CheckJSONPathLike:
+		val, err := jsonpath.Lookup([]byte(content), a.Path)
+		if err != nil {
+			return false, err.Error()
+		}
+		got := jsonpath.String(val)
+		if a.Type == adding.CheckJSONPathEq && got != a.Value {
+			return false, fmt.Sprintf("%s is %q, expected %q", a.Path, got, a.Value)
+		}
+		if a.Type == adding.CheckJSONPathLike {
+			re, err := regexp.Compile(a.Value)
+			if err != nil {
+				return false, err.Error()
+			}
+			if !re.MatchString(got) {
+				return false, fmt.Sprintf("%s is %q, expected to match %q", a.Path, got, a.Value)
+			}
+		}
+	case adding.CheckMaxLatency:
+		if elapsed > a.Latency {
+			return false, fmt.Sprintf("fetch took %.3fs, limit is %.3fs", elapsed, a.Latency)
+		}
+	default:
+		return false, fmt.Sprintf("unknown assertion type %q", a.Type)
+	}
+	return true, ""
+}
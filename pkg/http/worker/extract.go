@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/responding"
+	"github.com/gobuzz/pkg/jsonpath"
+)
+
+// Extract takes value of each of the extractors from fetched content
+// and response header. Values which cannot be found or converted to
+// extractor type are skipped.
+func Extract(extractors []adding.Extractor, content string, header http.Header) []responding.Sample {
+	if len(extractors) == 0 {
+		return nil
+	}
+
+	samples := make([]responding.Sample, 0, len(extractors))
+	for _, e := range extractors {
+		raw, err := extractRaw(e, content, header)
+		if err != nil {
+			log.Printf("Extractor %q: %v\n", e.Name, err)
+			continue
+		}
+
+		sample, err := toSample(e, raw)
+		if err != nil {
+			log.Printf("Extractor %q: %v\n", e.Name, err)
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+// extractRaw returns text form of value pointed by extractor.
+func extractRaw(e adding.Extractor, content string, header http.Header) (string, error) {
+	switch e.Source {
+	case adding.ExtractJSONPath:
+		val, err := jsonpath.Lookup([]byte(content), e.Expr)
+		if err != nil {
+			return "", err
+		}
+		return jsonpath.String(val), nil
+	case adding.ExtractRegex:
+		re, err := regexp.Compile(e.Expr)
+		if err != nil {
+			return "", err
+		}
+		match := re.FindStringSubmatch(content)
+		if match == nil || e.Group >= len(match) {
+			return "", fmt.Errorf("no match for %q", e.Expr)
+		}
+		return match[e.Group], nil
+	case adding.ExtractHeader:
+		if _, ok := header[http.CanonicalHeaderKey(e.Expr)]; !ok {
+			return "", fmt.Errorf("missing header %q", e.Expr)
+		}
+		return header.Get(e.Expr), nil
+	}
+	return "", fmt.Errorf("unknown source %q", e.Source)
+}
+
+// toSample converts raw value into sample of extractor type.
+func toSample(e adding.Extractor, raw string) (responding.Sample, error) {
+	sample := responding.Sample{Name: e.Name, Type: e.Type}
+	switch e.Type {
+	case adding.ValueString:
+		sample.Text = raw
+	case adding.ValueBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return sample, err
+		}
+		sample.Bool = b
+	default:
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return sample, err
+		}
+		sample.Type = adding.ValueNumber
+		sample.Number = n
+	}
+	return sample, nil
+}
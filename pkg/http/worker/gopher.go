@@ -1,62 +1,135 @@
 package worker
 
 import (
-	"bytes"
 	"context"
-	"io"
+	"fmt"
 	"log"
 	"net/http"
 	"time"
 
+	"github.com/gobuzz/pkg/domain/adding"
 	"github.com/gobuzz/pkg/domain/responding"
 	"github.com/gobuzz/pkg/format"
 )
 
 // Gopher definies task rules for working goroutine
 type Gopher struct {
-	ID       int
-	URL      string
-	Interval int
+	ID         string
+	Tenant     string
+	URL        string
+	Interval   int
+	Assertions []adding.Check
+	Extractors []adding.Extractor
+	Hosts      *HostLimiter // shared by all Gophers, nil means no limits
+	Client     *http.Client // of fetches, http.DefaultClient if nil
+	Immediate  bool         // first fetch runs at start, not after interval
+	Manual     bool         // fetches run on request, responses are marked manual
 }
 
 // GopherValidationStatus represents data stream body sending back
-// to GopherRun about fetchURL state during its execution.
+// to GopherRun about fetchURL state during its execution. Response is
+// the one fetchURL passed to recorder, nil if none was. Code and Header
+// are of upstream response, zero if URL was not fetched.
 type GopherValidationStatus struct {
-	Status int
-	Msg    string
+	Status   int
+	Msg      string
+	Response *responding.Response
+	Code     int
+	Header   http.Header
+}
+
+// recorder stores responses of fetchURL. Responding service stores
+// them in history, discard drops them.
+type recorder interface {
+	CreateRecord(record responding.Response) (int, error)
+}
+
+// discard is recorder of fetches which are not stored.
+type discard struct{}
+
+func (discard) CreateRecord(record responding.Response) (int, error) {
+	return 0, nil
 }
 
 // fetchURL gorotuine for Gopher internal usage. Fetch the conent from URL
 // mesure elapsed time from start till end of the request and pass these data to
-// repository of responding service.
-func fetchURL(goph *Gopher, respsr responding.Service, dataStream chan<- GopherValidationStatus) {
+// repository of responding service. Stopped Gopher cancels ctxParent.
+func fetchURL(ctxParent context.Context, goph *Gopher, respsr recorder, dataStream chan<- GopherValidationStatus) {
 
 	log.Println()
-	log.Printf("fetchURL[worker id:%d] - Start.\n", goph.ID)
-	defer log.Printf("fetchURL[worker id:%d] - Stop.\n", goph.ID)
+	log.Printf("fetchURL[worker id:%s] - Start.\n", goph.ID)
+	defer log.Printf("fetchURL[worker id:%s] - Stop.\n", goph.ID)
 	defer close(dataStream)
 
-	ctxParent := context.Background()
-	timeout := 5 * time.Second // GET request cancellation after 5s
-	ctxChild, cancel := context.WithTimeout(ctxParent, timeout)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctxChild, http.MethodGet, goph.URL, nil)
+	req, err := http.NewRequest(http.MethodGet, goph.URL, nil)
 	if err != nil {
 		log.Println("Error: ", err.Error())
 		record := responding.Response{
 			StorageKeyID: goph.ID,
+			Tenant:       goph.Tenant,
+			Manual:       goph.Manual,
+			Content:      "null",
+			Duration:     0,
+			Err:          err.Error(),
+		}
+		respsr.CreateRecord(record)
+		fault := GopherValidationStatus{Status: http.StatusBadRequest, Msg: err.Error(), Response: &record}
+		dataStream <- fault
+		return
+	}
+
+	if !goph.Hosts.Allowed(ctxParent, req.URL) {
+		log.Printf("fetchURL[worker id:%s] - Disallowed by robots.txt.\n", goph.ID)
+		record := responding.Response{
+			StorageKeyID: goph.ID,
+			Tenant:       goph.Tenant,
+			Manual:       goph.Manual,
+			Content:      "null",
+			Duration:     0,
+			Err:          "Fetch disallowed by robots.txt.",
+		}
+		respsr.CreateRecord(record)
+		fault := GopherValidationStatus{Status: http.StatusForbidden, Msg: "Fetch disallowed by robots.txt.", Response: &record}
+		dataStream <- fault
+		return
+	}
+
+	// Waiting for host limiter longer than interval would only pile up fetches.
+	ctxWait, cancelWait := context.WithTimeout(ctxParent, time.Duration(goph.Interval)*time.Second)
+	release, wait, err := goph.Hosts.Acquire(ctxWait, req.URL)
+	cancelWait()
+	delay := wait.Round(time.Millisecond).Seconds()
+	if err != nil {
+		log.Printf("fetchURL[worker id:%s] - Host limiter: %v\n", goph.ID, err)
+		record := responding.Response{
+			StorageKeyID: goph.ID,
+			Tenant:       goph.Tenant,
+			Manual:       goph.Manual,
 			Content:      "null",
 			Duration:     0,
+			Delay:        delay,
+			Err:          "Fetch skipped by host limiter.",
 		}
 		respsr.CreateRecord(record)
-		fault := GopherValidationStatus{Status: http.StatusBadRequest, Msg: err.Error()}
+		fault := GopherValidationStatus{Status: http.StatusAccepted, Msg: "Fetch skipped by host limiter.", Response: &record}
 		dataStream <- fault
 		return
 	}
+	defer release()
+
+	timeout := 5 * time.Second // GET request cancellation after 5s
+	ctxChild, cancel := context.WithTimeout(ctxParent, timeout)
+	defer cancel()
+
+	req = req.WithContext(ctxChild)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 
 	start := time.Now()
-	res, err := http.DefaultClient.Do(req)
+	client := goph.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
 	end := time.Now()
 	diff := end.Sub(start).Seconds()
 	elapsed := format.Duration(diff, 1000)
@@ -65,11 +138,15 @@ func fetchURL(goph *Gopher, respsr responding.Service, dataStream chan<- GopherV
 		log.Println("Request failed: ", err.Error())
 		record := responding.Response{
 			StorageKeyID: goph.ID,
+			Tenant:       goph.Tenant,
+			Manual:       goph.Manual,
+			Delay:        delay,
 			Content:      "null",
 			Duration:     0,
+			Err:          err.Error(),
 		}
 		respsr.CreateRecord(record)
-		fault := GopherValidationStatus{Status: http.StatusBadRequest, Msg: err.Error()}
+		fault := GopherValidationStatus{Status: http.StatusBadRequest, Msg: err.Error(), Response: &record}
 		dataStream <- fault
 		return
 	}
@@ -79,84 +156,126 @@ func fetchURL(goph *Gopher, respsr responding.Service, dataStream chan<- GopherV
 	if res.StatusCode == http.StatusNotFound {
 		record := responding.Response{
 			StorageKeyID: goph.ID,
+			Tenant:       goph.Tenant,
+			Manual:       goph.Manual,
+			Delay:        delay,
 			Content:      "null",
 			Duration:     0,
+			Err:          http.StatusText(http.StatusNotFound),
 		}
 		respsr.CreateRecord(record)
-		fault := GopherValidationStatus{Status: http.StatusNotFound, Msg: http.StatusText(http.StatusNotFound)}
+		fault := GopherValidationStatus{Status: http.StatusNotFound, Msg: http.StatusText(http.StatusNotFound), Response: &record, Code: res.StatusCode, Header: res.Header}
 		dataStream <- fault
 		return
 	}
 
 	if res.StatusCode == http.StatusOK {
-		var (
-			reader  io.Reader
-			resData bytes.Buffer
-		)
-
 		maxByteSize := int64(1 << 20) // 1MB limit
-		reader = io.LimitReader(res.Body, maxByteSize)
-
-		n, err := resData.ReadFrom(reader)
+		body, err := ReadBody(res, maxByteSize)
 		if err != nil {
 			log.Printf("Error reading the body: %v\n", err)
 			record := responding.Response{
 				StorageKeyID: goph.ID,
+				Tenant:       goph.Tenant,
+				Manual:       goph.Manual,
+				Delay:        delay,
 				Content:      "null",
 				Duration:     0,
+				Err:          err.Error(),
 			}
 			respsr.CreateRecord(record)
-			fault := GopherValidationStatus{Status: http.StatusBadRequest, Msg: http.StatusText(http.StatusBadRequest)}
+			fault := GopherValidationStatus{Status: http.StatusBadRequest, Msg: http.StatusText(http.StatusBadRequest), Response: &record, Code: res.StatusCode, Header: res.Header}
 			dataStream <- fault
 			return
 		}
 
+		content := string(body.Data)
 		record := responding.Response{
 			StorageKeyID: goph.ID,
-			Content:      resData.String(),
+			Tenant:       goph.Tenant,
+			Manual:       goph.Manual,
+			Delay:        delay,
 			Duration:     elapsed,
+			MediaType:    body.MediaType,
+			Assertions:   EvaluateChecks(goph.Assertions, content, elapsed),
+			Samples:      Extract(goph.Extractors, content, res.Header),
+		}
+		if body.Binary {
+			record.Data = body.Data
+		} else {
+			record.Content = content
 		}
 
-		servValid := respsr.CreateRecord(record)
+		recordID, err := respsr.CreateRecord(record)
 
-		log.Printf("Data content: %s read bytes: %d\n", resData.String(), n)
-		log.Println("DefaultClient response recived, status code:", res.StatusCode)
+		log.Printf("Data content: %s read bytes: %d\n", body.MediaType, len(body.Data))
+		log.Println("Client response recived, status code:", res.StatusCode)
 		log.Println("Responser service:")
-		log.Println("Status code:", servValid.Status)
-		log.Printf("Validation msg: %s | response db key = %d\n", servValid.Msg, goph.ID)
-		log.Println("Added record key:", servValid.StorageKeyID)
-		fault := GopherValidationStatus{Status: http.StatusAccepted, Msg: "Adding record into resp db was succeed."}
+		if err != nil {
+			log.Printf("Adding record failed: %v | response db key = %s\n", err, goph.ID)
+		}
+		log.Println("Added record key:", recordID)
+
+		if record.Failed() { // Failed assertion counts as fetch failure
+			log.Printf("fetchURL[worker id:%s] - Assertions failed: %v\n", goph.ID, record.Assertions)
+			fault := GopherValidationStatus{Status: http.StatusExpectationFailed, Msg: "Fetch assertions failed.", Response: &record, Code: res.StatusCode, Header: res.Header}
+			dataStream <- fault
+			return
+		}
+
+		fault := GopherValidationStatus{Status: http.StatusAccepted, Msg: "Adding record into resp db was succeed.", Response: &record, Code: res.StatusCode, Header: res.Header}
 		dataStream <- fault
 		return
 	}
-	log.Println(err.Error())
-	fault := GopherValidationStatus{Status: http.StatusBadRequest, Msg: "Something goes wrong."}
+	log.Println("Unexpected status code:", res.StatusCode)
+	record := responding.Response{
+		StorageKeyID: goph.ID,
+		Tenant:       goph.Tenant,
+		Manual:       goph.Manual,
+		Delay:        delay,
+		Content:      "null",
+		Duration:     0,
+		Err:          fmt.Sprintf("Unexpected status code %d %s.", res.StatusCode, http.StatusText(res.StatusCode)),
+	}
+	respsr.CreateRecord(record)
+	fault := GopherValidationStatus{Status: http.StatusBadRequest, Msg: record.Err, Response: &record, Code: res.StatusCode, Header: res.Header}
 	dataStream <- fault
 	return
 }
 
-// GopherRun is a background goroutine for fetching data for individual requests
-func GopherRun(goph *Gopher, respsr responding.Service) GopherValidationStatus {
+// GopherRun is a background goroutine for fetching data for individual requests.
+// It runs until fetch fails or ctx is cancelled.
+func GopherRun(ctx context.Context, goph *Gopher, respsr responding.Service) GopherValidationStatus {
 
-	log.Printf("Worker[id:%d] - Start\n", goph.ID)
-	defer log.Printf("Worker[id:%d] - Stop\n", goph.ID)
+	log.Printf("Worker[id:%s] - Start\n", goph.ID)
+	defer log.Printf("Worker[id:%s] - Stop\n", goph.ID)
 
 	interval := time.Duration(goph.Interval) * time.Second
 	halt := 20 * time.Minute
-	dataStream := make(chan GopherValidationStatus)
+	var dataStream chan GopherValidationStatus // of running fetch, nil blocks
 	var dataRecived GopherValidationStatus
 
+	if goph.Immediate {
+		dataStream = make(chan GopherValidationStatus, 1)
+		go fetchURL(ctx, goph, &respsr, dataStream)
+	}
+
 	for {
 		select {
 		case <-time.After(interval):
-			go fetchURL(goph, respsr, dataStream) // TO-DO: Think about using wg here.
+			dataStream = make(chan GopherValidationStatus, 1) // fetchURL never blocks on send
+			go fetchURL(ctx, goph, &respsr, dataStream)
 		case res := <-dataStream:
+			dataStream = nil
 			if res.Status != http.StatusAccepted {
 				dataRecived = GopherValidationStatus{Status: res.Status, Msg: res.Msg}
 				return dataRecived
 			}
+		case <-ctx.Done():
+			dataRecived = GopherValidationStatus{Status: http.StatusGone, Msg: "Worker stopped."}
+			return dataRecived
 		case <-time.After(halt):
-			log.Printf("Worker[id:%d]: Timeout because of 20 min halt.", goph.ID)
+			log.Printf("Worker[id:%s]: Timeout because of 20 min halt.", goph.ID)
 			dataRecived = GopherValidationStatus{Status: http.StatusRequestTimeout, Msg: http.StatusText(http.StatusRequestTimeout)}
 			return dataRecived
 		}
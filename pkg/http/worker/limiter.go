@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobuzz/pkg/ratelimit"
+)
+
+// HostLimits defines politeness rules applied to each upstream host.
+// Zero value of a field means no limit.
+type HostLimits struct {
+	MaxConcurrent int     // requests in flight
+	PerSecond     float64 // requests started each second
+	Robots        bool    // respect robots.txt
+}
+
+// HostLimiter limits requests sent by all Gophers to the same host.
+// Nil limiter does not limit anything.
+type HostLimiter struct {
+	Client *http.Client // fetching robots.txt, http.DefaultClient if nil
+	limits HostLimits
+	rate   *ratelimit.Limiter
+	robots *robotsCache
+	slots  map[string]chan struct{}
+	mu     sync.Mutex
+}
+
+// NewHostLimiter creates limiter shared by Gophers.
+func NewHostLimiter(limits HostLimits) *HostLimiter {
+	return &HostLimiter{
+		limits: limits,
+		rate:   ratelimit.NewLimiter(ratelimit.Rate{PerSecond: limits.PerSecond}),
+		robots: newRobotsCache(time.Hour),
+		slots:  make(map[string]chan struct{}),
+	}
+}
+
+// Acquire waits until request to u can be sent. Returned release func
+// must be called when request is done. Time spent waiting is returned
+// also on failure.
+func (l *HostLimiter) Acquire(ctx context.Context, u *url.URL) (release func(), delay time.Duration, err error) {
+	start := time.Now()
+	if l == nil {
+		return func() {}, 0, nil
+	}
+
+	host := strings.ToLower(u.Host)
+	slot := l.slot(host)
+	if slot != nil {
+		select {
+		case slot <- struct{}{}:
+		case <-ctx.Done():
+			return nil, time.Since(start), ctx.Err()
+		}
+	}
+	release = func() {
+		if slot != nil {
+			<-slot
+		}
+	}
+
+	for {
+		ok, wait := l.rate.Allow(host, time.Now())
+		if ok {
+			break
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			release()
+			return nil, time.Since(start), ctx.Err()
+		}
+	}
+	return release, time.Since(start), nil
+}
+
+// Allowed reports whether robots.txt of u host allows fetching u.
+// It is always true if limiter does not respect robots.txt.
+func (l *HostLimiter) Allowed(ctx context.Context, u *url.URL) bool {
+	if l == nil || !l.limits.Robots {
+		return true
+	}
+	client := l.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return l.robots.allowed(ctx, client, u)
+}
+
+// slot returns semaphore of host or nil if concurrency is not limited.
+func (l *HostLimiter) slot(host string) chan struct{} {
+	if l.limits.MaxConcurrent <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.slots[host]
+	if !ok {
+		s = make(chan struct{}, l.limits.MaxConcurrent)
+		l.slots[host] = s
+	}
+	return s
+}
@@ -0,0 +1,261 @@
+package worker
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+)
+
+// Limits of manual fetch run and of dry run.
+const (
+	RunTimeout    = 10 * time.Second // waited for result by default
+	MaxRunTimeout = time.Minute
+	MaxTryBody    = 4 << 10 // body bytes sent back by dry run
+)
+
+// Pool runs Gophers of fetches, at most one per fetch, and stops them
+// on request. Gophers share hosts limiter and HTTP client.
+type Pool struct {
+	respsr  responding.Service
+	hosts   *HostLimiter
+	client  *http.Client
+	mu      sync.Mutex
+	running map[poolKey]*poolRun
+	paused  map[poolKey]*poolPause
+}
+
+// poolKey identifies fetch of the Gopher.
+type poolKey struct {
+	tenant string
+	id     string
+}
+
+// poolRun is a running Gopher.
+type poolRun struct {
+	cancel context.CancelFunc
+}
+
+// poolPause is a Gopher stopped until resumed. Timer resumes it at pause
+// end, it is nil if pause has no end.
+type poolPause struct {
+	fetch adding.Fetch
+	timer *time.Timer
+}
+
+// NewPool creates a pool of Gophers storing responses with respsr and
+// fetching with client, http.DefaultClient if nil. Hosts limiter without
+// its own client fetches robots.txt with client too.
+func NewPool(respsr responding.Service, hosts *HostLimiter, client *http.Client) *Pool {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if hosts != nil && hosts.Client == nil {
+		hosts.Client = client
+	}
+	return &Pool{respsr: respsr, hosts: hosts, client: client, running: make(map[poolKey]*poolRun), paused: make(map[poolKey]*poolPause)}
+}
+
+// Start runs Gopher of tenant fetch with given ID. Gopher already
+// running for the fetch is stopped first, so Start also restarts
+// updated fetches. Paused fetch is not started, its definition is
+// replaced for the time it is resumed.
+func (p *Pool) Start(id string, f adding.Fetch) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := poolKey{tenant: f.Tenant, id: id}
+	if pause, ok := p.paused[k]; ok {
+		pause.fetch = f
+		return
+	}
+	p.start(k, f, false)
+}
+
+// StartNow runs Gopher of tenant fetch with given ID like Start, but its
+// first fetch runs at once instead of after the interval.
+func (p *Pool) StartNow(id string, f adding.Fetch) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := poolKey{tenant: f.Tenant, id: id}
+	if pause, ok := p.paused[k]; ok {
+		pause.fetch = f
+		return
+	}
+	p.start(k, f, true)
+}
+
+// start runs Gopher of fetch identified by k, fetching at once if now is
+// set. Must be called with p.mu held.
+func (p *Pool) start(k poolKey, f adding.Fetch, now bool) {
+	goph := p.gopher(k.id, f)
+	goph.Immediate = now
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &poolRun{cancel: cancel}
+
+	if old, ok := p.running[k]; ok {
+		old.cancel()
+	}
+	p.running[k] = run
+
+	go func() {
+		GopherRun(ctx, goph, p.respsr)
+		cancel()
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.running[k] == run { // not restarted meanwhile
+			delete(p.running, k)
+		}
+	}()
+}
+
+// Stop stops Gopher of tenant fetch with given ID if it runs. Pause of
+// the fetch is dropped.
+func (p *Pool) Stop(tenant, id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := poolKey{tenant: tenant, id: id}
+	p.unpause(k)
+	if run, ok := p.running[k]; ok {
+		run.cancel()
+		delete(p.running, k)
+	}
+}
+
+// Pause stops Gopher of tenant fetch with given ID until Resume, or until
+// given time if it is not zero. Gopher is started again with f then.
+func (p *Pool) Pause(id string, f adding.Fetch, until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := poolKey{tenant: f.Tenant, id: id}
+	p.unpause(k)
+	if run, ok := p.running[k]; ok {
+		run.cancel()
+		delete(p.running, k)
+	}
+
+	pause := &poolPause{fetch: f}
+	if !until.IsZero() {
+		pause.timer = time.AfterFunc(time.Until(until), func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if p.paused[k] == pause { // not resumed or paused again meanwhile
+				delete(p.paused, k)
+				p.start(k, pause.fetch, false)
+			}
+		})
+	}
+	p.paused[k] = pause
+}
+
+// Resume starts Gopher of paused tenant fetch with given ID. Definition
+// replaced while the fetch was paused takes precedence over f. Returns
+// false, leaving Gopher as is, if the fetch is not paused.
+func (p *Pool) Resume(id string, f adding.Fetch) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := poolKey{tenant: f.Tenant, id: id}
+	pause, ok := p.paused[k]
+	if !ok {
+		return false
+	}
+	p.unpause(k)
+	p.start(k, pause.fetch, false)
+	return true
+}
+
+// Restore runs Gopher of stored tenant fetch f the way it was left, so
+// paused fetch stays paused until its pause end.
+func (p *Pool) Restore(tenant string, f listing.Fetch) {
+	if !f.Paused {
+		p.Start(f.ID, f.Definition(tenant))
+		return
+	}
+
+	var until time.Time
+	if f.PausedUntil != nil {
+		until = *f.PausedUntil
+	}
+	p.Pause(f.ID, f.Definition(tenant), until)
+}
+
+// Run fetches tenant fetch with given ID once, aside from its Gopher
+// which keeps its schedule, and waits for the result until ctx is done.
+// Response is stored in history marked manual.
+func (p *Pool) Run(ctx context.Context, id string, f adding.Fetch) GopherValidationStatus {
+	goph := p.gopher(id, f)
+	goph.Manual = true
+	return wait(ctx, goph, &p.respsr)
+}
+
+// Try fetches f once the way its Gopher would and waits for the result
+// until ctx is done. Nothing is stored.
+func (p *Pool) Try(ctx context.Context, f adding.Fetch) GopherValidationStatus {
+	return wait(ctx, p.gopher("", f), discard{})
+}
+
+// wait runs fetchURL of goph storing response with rec and returns its
+// result, or timeout status if ctx is done first.
+func wait(ctx context.Context, goph *Gopher, rec recorder) GopherValidationStatus {
+	dataStream := make(chan GopherValidationStatus, 1) // fetchURL never blocks on send
+	go fetchURL(ctx, goph, rec, dataStream)
+
+	select {
+	case res := <-dataStream:
+		return res
+	case <-ctx.Done():
+		return GopherValidationStatus{Status: http.StatusGatewayTimeout, Msg: "Fetch did not finish in time."}
+	}
+}
+
+// gopher returns Gopher of fetch with given ID.
+func (p *Pool) gopher(id string, f adding.Fetch) *Gopher {
+	return &Gopher{
+		ID:         id,
+		Tenant:     f.Tenant,
+		URL:        f.URL,
+		Interval:   f.Interval,
+		Assertions: f.Assertions,
+		Extractors: f.Extractors,
+		Hosts:      p.hosts,
+		Client:     p.client,
+	}
+}
+
+// unpause drops pause of fetch identified by k. Must be called with p.mu
+// held.
+func (p *Pool) unpause(k poolKey) {
+	if pause, ok := p.paused[k]; ok {
+		if pause.timer != nil {
+			pause.timer.Stop()
+		}
+		delete(p.paused, k)
+	}
+}
+
+// Running reports whether Gopher of tenant fetch with given ID runs.
+func (p *Pool) Running(tenant, id string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, ok := p.running[poolKey{tenant: tenant, id: id}]
+	return ok
+}
+
+// Paused reports whether Gopher of tenant fetch with given ID is paused.
+func (p *Pool) Paused(tenant, id string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, ok := p.paused[poolKey{tenant: tenant, id: id}]
+	return ok
+}
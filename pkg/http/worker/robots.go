@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// userAgent identifies Gophers in robots.txt groups.
+const userAgent = "gobuzz"
+
+// Robots holds Allow and Disallow rules of robots.txt group which
+// applies to Gophers.
+type Robots struct {
+	allow    []string
+	disallow []string
+}
+
+// ParseRobots reads robots.txt rules of the group matching Gophers user
+// agent, or of "*" group if there is no such group.
+func ParseRobots(r io.Reader) Robots {
+	var (
+		own, any Robots
+		hasOwn   bool
+		agents   []string // user agents of current group
+		inRules  bool     // current group has rules already
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		field := strings.ToLower(strings.TrimSpace(parts[0]))
+		value := strings.TrimSpace(parts[1])
+
+		switch field {
+		case "user-agent":
+			if inRules { // new group starts
+				agents, inRules = nil, false
+			}
+			agents = append(agents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			for _, agent := range agents {
+				switch {
+				case strings.Contains(agent, userAgent):
+					own.add(field, value)
+					hasOwn = true
+				case agent == "*":
+					any.add(field, value)
+				}
+			}
+		}
+	}
+
+	if hasOwn {
+		return own
+	}
+	return any
+}
+
+// add appends path prefix to allow or disallow rules. Empty path
+// matches nothing.
+func (r *Robots) add(field, path string) {
+	switch {
+	case path == "":
+		return
+	case field == "allow":
+		r.allow = append(r.allow, path)
+	default:
+		r.disallow = append(r.disallow, path)
+	}
+}
+
+// Allowed reports whether path may be fetched. The longest matching
+// rule wins, Allow wins ties.
+func (r Robots) Allowed(path string) bool {
+	allowed, longest := true, -1
+	for _, p := range r.disallow {
+		if strings.HasPrefix(path, p) && len(p) > longest {
+			allowed, longest = false, len(p)
+		}
+	}
+	for _, p := range r.allow {
+		if strings.HasPrefix(path, p) && len(p) >= longest {
+			allowed, longest = true, len(p)
+		}
+	}
+	return allowed
+}
+
+// robotsEntry is robots.txt of a single host.
+type robotsEntry struct {
+	robots  Robots
+	fetched time.Time
+}
+
+// robotsCache keeps robots.txt rules of each host for ttl.
+type robotsCache struct {
+	ttl   time.Duration
+	hosts map[string]robotsEntry
+	mu    sync.Mutex
+}
+
+func newRobotsCache(ttl time.Duration) *robotsCache {
+	return &robotsCache{ttl: ttl, hosts: make(map[string]robotsEntry)}
+}
+
+// allowed reports whether robots.txt of u host, fetched with client,
+// allows fetching u. Hosts without readable robots.txt allow everything.
+func (c *robotsCache) allowed(ctx context.Context, client *http.Client, u *url.URL) bool {
+	key := u.Scheme + "://" + strings.ToLower(u.Host)
+
+	c.mu.Lock()
+	entry, ok := c.hosts[key]
+	c.mu.Unlock()
+
+	if !ok || time.Since(entry.fetched) > c.ttl {
+		entry = robotsEntry{robots: fetchRobots(ctx, client, key+"/robots.txt"), fetched: time.Now()}
+		c.mu.Lock()
+		c.hosts[key] = entry
+		c.mu.Unlock()
+	}
+	return entry.robots.Allowed(u.EscapedPath())
+}
+
+// fetchRobots downloads and parses robots.txt with client. Any failure
+// results in empty rules.
+func fetchRobots(ctx context.Context, client *http.Client, robotsURL string) Robots {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return Robots{}
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return Robots{}
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Robots{}
+	}
+	return ParseRobots(io.LimitReader(res.Body, 512<<10))
+}
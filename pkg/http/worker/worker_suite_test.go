@@ -1,6 +1,7 @@
 package worker_test
 
 import (
+	"net/http"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
@@ -11,3 +12,10 @@ func TestWorker(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Worker Suite")
 }
+
+// upstream answers requests of a client instead of the network.
+type upstream func(r *http.Request) (*http.Response, error)
+
+func (u upstream) RoundTrip(r *http.Request) (*http.Response, error) {
+	return u(r)
+}
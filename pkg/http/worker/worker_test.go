@@ -1,6 +1,22 @@
 package worker_test
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -9,8 +25,417 @@ import (
 
 var _ = Describe("Worker", func() {
 
-	var (
-		
-	)
+	Describe("When calling EvaluateChecks", func() {
+		var (
+			content    string
+			assertions []adding.Check
+		)
+
+		BeforeEach(func() { // Configuration
+			content = `{"status":"ok","count":15}`
+		})
+
+		Context("When content meets all assertions.", func() {
+			BeforeEach(func() {
+				assertions = []adding.Check{
+					{Type: adding.CheckContains, Value: `"status"`},
+					{Type: adding.CheckNotContains, Value: "error"},
+					{Type: adding.CheckRegex, Value: `count":\d+`},
+					{Type: adding.CheckJSONPathEq, Path: "$.status", Value: "ok"},
+					{Type: adding.CheckJSONPathLike, Path: "$.count", Value: `^1\d$`},
+					{Type: adding.CheckMaxLatency, Latency: 0.5},
+				}
+			})
+
+			It("Should return passed result for each assertion.", func() {
+				results := EvaluateChecks(assertions, content, 0.2)
+				Expect(results).To(HaveLen(len(assertions)))
+				for i, res := range results {
+					Expect(res.Type).To(Equal(assertions[i].Type))
+					Expect(res.Passed).To(BeTrue())
+					Expect(res.Msg).To(BeEmpty())
+				}
+			})
+		})
+
+		Context("When content does not meet assertions.", func() {
+			BeforeEach(func() {
+				assertions = []adding.Check{
+					{Type: adding.CheckContains, Value: "error"},
+					{Type: adding.CheckNotContains, Value: "ok"},
+					{Type: adding.CheckRegex, Value: `^\[`},
+					{Type: adding.CheckJSONPathEq, Path: "$.status", Value: "down"},
+					{Type: adding.CheckJSONPathLike, Path: "$.missing", Value: ".*"},
+					{Type: adding.CheckMaxLatency, Latency: 0.1},
+				}
+			})
+
+			It("Should return failed result with msg for each assertion.", func() {
+				results := EvaluateChecks(assertions, content, 0.2)
+				Expect(results).To(HaveLen(len(assertions)))
+				for _, res := range results {
+					Expect(res.Passed).To(BeFalse())
+					Expect(res.Msg).NotTo(BeEmpty())
+				}
+			})
+		})
+
+		Context("When there are no assertions.", func() {
+			It("Should return nil.", func() {
+				Expect(EvaluateChecks(nil, content, 0.2)).To(BeNil())
+			})
+		})
+	})
+
+	Describe("When calling Extract", func() {
+		var (
+			content string
+			header  http.Header
+		)
+
+		BeforeEach(func() { // Configuration
+			content = `{"load":0.75,"up":true,"version":"v1.2","build":"build=42"}`
+			header = http.Header{"X-Requests": []string{"17"}}
+		})
+
+		It("Should return typed sample for each value found.", func() {
+			extractors := []adding.Extractor{
+				{Name: "load", Source: adding.ExtractJSONPath, Expr: "$.load"},
+				{Name: "up", Source: adding.ExtractJSONPath, Expr: "$.up", Type: adding.ValueBool},
+				{Name: "version", Source: adding.ExtractJSONPath, Expr: "$.version", Type: adding.ValueString},
+				{Name: "build", Source: adding.ExtractRegex, Expr: `build=(\d+)`, Group: 1},
+				{Name: "requests", Source: adding.ExtractHeader, Expr: "x-requests"},
+			}
+			Expect(Extract(extractors, content, header)).To(Equal([]responding.Sample{
+				{Name: "load", Type: adding.ValueNumber, Number: 0.75},
+				{Name: "up", Type: adding.ValueBool, Bool: true},
+				{Name: "version", Type: adding.ValueString, Text: "v1.2"},
+				{Name: "build", Type: adding.ValueNumber, Number: 42},
+				{Name: "requests", Type: adding.ValueNumber, Number: 17},
+			}))
+		})
+
+		It("Should skip values which are missing or have invalid type.", func() {
+			extractors := []adding.Extractor{
+				{Name: "missing", Source: adding.ExtractJSONPath, Expr: "$.missing"},
+				{Name: "version", Source: adding.ExtractJSONPath, Expr: "$.version"},
+				{Name: "date", Source: adding.ExtractHeader, Expr: "Date"},
+			}
+			Expect(Extract(extractors, content, header)).To(BeEmpty())
+		})
+	})
+
+	Describe("When calling ReadBody", func() {
+		var response func(header http.Header, body []byte) *http.Response
+
+		BeforeEach(func() { // Configuration
+			response = func(header http.Header, body []byte) *http.Response {
+				return &http.Response{Header: header, Body: ioutil.NopCloser(bytes.NewReader(body))}
+			}
+		})
+
+		Context("When body is compressed.", func() {
+			It("Should return decompressed body for each encoding.", func() {
+				text := []byte(`{"status":"ok"}`)
+				var gz, zl, fl, br bytes.Buffer
+				gw := gzip.NewWriter(&gz)
+				gw.Write(text)
+				gw.Close()
+				zw := zlib.NewWriter(&zl)
+				zw.Write(text)
+				zw.Close()
+				fw, _ := flate.NewWriter(&fl, flate.DefaultCompression)
+				fw.Write(text)
+				fw.Close()
+				bw := brotli.NewWriter(&br)
+				bw.Write(text)
+				bw.Close()
+
+				for encoding, data := range map[string][]byte{"gzip": gz.Bytes(), "deflate": zl.Bytes(), "br": br.Bytes(), "": text} {
+					header := http.Header{"Content-Type": {"application/json"}, "Content-Encoding": {encoding}}
+					body, err := ReadBody(response(header, data), 1<<20)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(Equal(Body{Data: text, MediaType: "application/json"}))
+				}
+
+				header := http.Header{"Content-Type": {"application/json"}, "Content-Encoding": {"deflate"}}
+				body, err := ReadBody(response(header, fl.Bytes()), 1<<20) // raw deflate
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Data).To(Equal(text))
+			})
+
+			It("Should return error for unsupported encoding.", func() {
+				header := http.Header{"Content-Encoding": {"compress"}}
+				_, err := ReadBody(response(header, []byte("abc")), 1<<20)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("When text body has non UTF-8 charset.", func() {
+			It("Should return body converted to UTF-8.", func() {
+				header := http.Header{"Content-Type": {"text/plain; charset=ISO-8859-1"}}
+				body, err := ReadBody(response(header, []byte{'c', 'a', 'f', 0xe9}), 1<<20)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body.Data)).To(Equal("café"))
+				Expect(body.Binary).To(BeFalse())
+			})
+		})
+
+		Context("When body is binary.", func() {
+			It("Should return raw bytes with media type.", func() {
+				png := []byte("\x89PNG\r\n\x1a\n\x00\x00")
+				body, err := ReadBody(response(http.Header{}, png), 1<<20)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(Equal(Body{Data: png, MediaType: "image/png", Binary: true}))
+			})
+		})
+
+		Context("When body is larger than limit.", func() {
+			It("Should return first limit bytes.", func() {
+				header := http.Header{"Content-Type": {"text/plain"}}
+				body, err := ReadBody(response(header, []byte("abcdef")), 3)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body.Data)).To(Equal("abc"))
+			})
+
+			It("Should drop partial rune of text at limit.", func() {
+				header := http.Header{"Content-Type": {"text/plain; charset=utf-8"}}
+				body, err := ReadBody(response(header, []byte("ab€")), 4) // € is 3 bytes
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(Equal(Body{Data: []byte("ab"), MediaType: "text/plain"}))
+
+				body, err = ReadBody(response(header, []byte("ab€c")), 5)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(Equal(Body{Data: []byte("ab€"), MediaType: "text/plain"}))
+			})
+		})
+	})
+
+	Describe("When calling Truncate", func() {
+		It("Should cut text at rune start and binary at limit.", func() {
+			text := responding.Response{Content: "aéé"}
+			Expect(Truncate(&text, 5)).To(BeFalse())
+			Expect(Truncate(&text, 4)).To(BeTrue())
+			Expect(text.Content).To(Equal("aé"))
+
+			binary := responding.Response{Data: []byte{1, 2, 3}}
+			Expect(Truncate(&binary, 2)).To(BeTrue())
+			Expect(binary.Data).To(Equal([]byte{1, 2}))
+		})
+	})
+
+	Describe("When calling ParseRobots", func() {
+		robots := `# comment
+User-agent: *
+Disallow: /private
+Allow: /private/public
+
+User-agent: otherbot
+Disallow: /
+`
+		It("Should apply rules of * group.", func() {
+			r := ParseRobots(strings.NewReader(robots))
+			Expect(r.Allowed("/range/15")).To(BeTrue())
+			Expect(r.Allowed("/private/data")).To(BeFalse())
+			Expect(r.Allowed("/private/public/data")).To(BeTrue())
+		})
+
+		It("Should prefer group of gobuzz user agent.", func() {
+			r := ParseRobots(strings.NewReader(robots + "\nUser-agent: GoBuzz\nDisallow: /delay\n"))
+			Expect(r.Allowed("/private/data")).To(BeTrue())
+			Expect(r.Allowed("/delay/3")).To(BeFalse())
+		})
+	})
+
+	Describe("When calling HostLimiter.Acquire", func() {
+		var (
+			u1, _ = url.Parse("https://httpbin.org/range/15")
+			u2, _ = url.Parse("https://example.com/range/15")
+		)
+
+		It("Should limit concurrent requests per host.", func() {
+			limiter := NewHostLimiter(HostLimits{MaxConcurrent: 1})
+			release, _, err := limiter.Acquire(context.Background(), u1)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = limiter.Acquire(context.Background(), u2)
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			_, delay, err := limiter.Acquire(ctx, u1)
+			Expect(err).To(HaveOccurred())
+			Expect(delay).To(BeNumerically(">=", 20*time.Millisecond))
+
+			release()
+			_, _, err = limiter.Acquire(context.Background(), u1)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should read robots.txt with its client.", func() {
+			limiter := NewHostLimiter(HostLimits{Robots: true})
+			limiter.Client = &http.Client{Transport: upstream(func(r *http.Request) (*http.Response, error) {
+				Expect(r.URL.String()).To(Equal("https://robots.invalid/robots.txt"))
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader("User-agent: *\nDisallow: /private\n")),
+					Request:    r,
+				}, nil
+			})}
+			private, _ := url.Parse("https://robots.invalid/private/1")
+			public, _ := url.Parse("https://robots.invalid/public/1")
+			Expect(limiter.Allowed(context.Background(), private)).To(BeFalse())
+			Expect(limiter.Allowed(context.Background(), public)).To(BeTrue())
+		})
+
+		It("Should delay requests over host rate.", func() {
+			limiter := NewHostLimiter(HostLimits{PerSecond: 20})
+			_, delay, _ := limiter.Acquire(context.Background(), u1)
+			Expect(delay).To(BeNumerically("<", 10*time.Millisecond))
+			_, delay, _ = limiter.Acquire(context.Background(), u1)
+			Expect(delay).To(BeNumerically(">=", 40*time.Millisecond))
+		})
+
+		It("Should not limit anything when limiter is nil.", func() {
+			var limiter *HostLimiter
+			release, delay, err := limiter.Acquire(context.Background(), u1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delay).To(BeZero())
+			release()
+			Expect(limiter.Allowed(context.Background(), u1)).To(BeTrue())
+		})
+	})
+
+	Describe("When calling Pool", func() {
+		var (
+			pool  *Pool
+			fetch adding.Fetch
+		)
+
+		BeforeEach(func() {
+			pool = NewPool(responding.NewService(new(responding.FakeRepositoryAdder)), nil, nil)
+			fetch = adding.Fetch{Tenant: "default", URL: "https://httpbin.org/range/15", Interval: 3600}
+		})
+
+		It("Should run a single Gopher per fetch until stopped.", func() {
+			pool.Start(adding.FakeID, fetch)
+			pool.Start(adding.FakeID, fetch) // restart
+			Expect(pool.Running("default", adding.FakeID)).To(BeTrue())
+			Expect(pool.Running("other", adding.FakeID)).To(BeFalse())
+
+			pool.Stop("default", adding.FakeID)
+			Expect(pool.Running("default", adding.FakeID)).To(BeFalse())
+			Consistently(func() bool { return pool.Running("default", adding.FakeID) }, "50ms").Should(BeFalse())
+		})
+
+		It("Should not run paused Gopher until resumed.", func() {
+			pool.Start(adding.FakeID, fetch)
+			pool.Pause(adding.FakeID, fetch, time.Time{})
+			Expect(pool.Running("default", adding.FakeID)).To(BeFalse())
+			Expect(pool.Paused("default", adding.FakeID)).To(BeTrue())
+
+			pool.Start(adding.FakeID, fetch) // update of paused fetch
+			Expect(pool.Running("default", adding.FakeID)).To(BeFalse())
+
+			Expect(pool.Resume(adding.FakeID, fetch)).To(BeTrue())
+			Expect(pool.Running("default", adding.FakeID)).To(BeTrue())
+			Expect(pool.Paused("default", adding.FakeID)).To(BeFalse())
+			pool.Stop("default", adding.FakeID)
+		})
+
+		It("Should not resume Gopher which is not paused.", func() {
+			Expect(pool.Resume(adding.FakeID, fetch)).To(BeFalse())
+			Expect(pool.Running("default", adding.FakeID)).To(BeFalse())
+		})
+
+		It("Should resume paused Gopher at pause end.", func() {
+			pool.Pause(adding.FakeID, fetch, time.Now().Add(20*time.Millisecond))
+			Expect(pool.Running("default", adding.FakeID)).To(BeFalse())
+			Eventually(func() bool { return pool.Running("default", adding.FakeID) }).Should(BeTrue())
+			Expect(pool.Paused("default", adding.FakeID)).To(BeFalse())
+			pool.Stop("default", adding.FakeID)
+		})
+
+		It("Should restore stored pause of fetch.", func() {
+			until := time.Now().Add(20 * time.Millisecond)
+			pool.Restore("default", listing.Fetch{ID: adding.FakeID, URL: fetch.URL, Interval: fetch.Interval, Paused: true, PausedUntil: &until})
+			pool.Restore("default", listing.Fetch{ID: "01HZX3V6Q8J5K2M9N4P7R1S3T6", URL: fetch.URL, Interval: fetch.Interval, Paused: true})
+			pool.Restore("default", listing.Fetch{ID: "01HZX3V6Q8J5K2M9N4P7R1S3T7", URL: fetch.URL, Interval: fetch.Interval})
+			Expect(pool.Paused("default", adding.FakeID)).To(BeTrue())
+			Expect(pool.Paused("default", "01HZX3V6Q8J5K2M9N4P7R1S3T6")).To(BeTrue())
+			Expect(pool.Running("default", "01HZX3V6Q8J5K2M9N4P7R1S3T7")).To(BeTrue())
+
+			Eventually(func() bool { return pool.Running("default", adding.FakeID) }).Should(BeTrue())
+			Expect(pool.Paused("default", "01HZX3V6Q8J5K2M9N4P7R1S3T6")).To(BeTrue())
+			pool.Stop("default", adding.FakeID)
+			pool.Stop("default", "01HZX3V6Q8J5K2M9N4P7R1S3T6")
+			pool.Stop("default", "01HZX3V6Q8J5K2M9N4P7R1S3T7")
+		})
+
+		Context("When running fetch manually", func() {
+			var (
+				server  *httptest.Server
+				release chan struct{}
+			)
+
+			BeforeEach(func() {
+				release = make(chan struct{})
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.URL.Path {
+					case "/slow":
+						<-release
+					case "/down":
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					w.Header().Set("Content-Type", "text/plain")
+					w.Write([]byte("abc"))
+				}))
+			})
+
+			AfterEach(func() {
+				close(release)
+				server.Close()
+			})
+
+			It("Should return manual response without running Gopher.", func() {
+				fetch.URL = server.URL + "/fast"
+				res := pool.Run(context.Background(), adding.FakeID, fetch)
+				Expect(res.Status).To(Equal(http.StatusAccepted))
+				Expect(res.Response).NotTo(BeNil())
+				Expect(res.Response.Content).To(Equal("abc"))
+				Expect(res.Response.Manual).To(BeTrue())
+				Expect(pool.Running("default", adding.FakeID)).To(BeFalse())
+			})
+
+			It("Should return manual response of unexpected upstream status.", func() {
+				fetch.URL = server.URL + "/down"
+				res := pool.Run(context.Background(), adding.FakeID, fetch)
+				Expect(res.Status).To(Equal(http.StatusBadRequest))
+				Expect(res.Code).To(Equal(http.StatusServiceUnavailable))
+				Expect(res.Response).NotTo(BeNil())
+				Expect(res.Response.Err).To(Equal("Unexpected status code 503 Service Unavailable."))
+				Expect(res.Response.Manual).To(BeTrue())
+			})
+
+			It("Should try fetch with upstream status and headers.", func() {
+				fetch.URL = server.URL + "/fast"
+				res := pool.Try(context.Background(), fetch)
+				Expect(res.Status).To(Equal(http.StatusAccepted))
+				Expect(res.Code).To(Equal(http.StatusOK))
+				Expect(res.Header.Get("Content-Type")).To(Equal("text/plain"))
+				Expect(res.Response.Content).To(Equal("abc"))
+				Expect(res.Response.Manual).To(BeFalse())
+			})
 
+			It("Should stop waiting when context is done.", func() {
+				fetch.URL = server.URL + "/slow"
+				ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+				defer cancel()
+				res := pool.Run(ctx, adding.FakeID, fetch)
+				Expect(res.Status).To(Equal(http.StatusGatewayTimeout))
+				Expect(res.Response).To(BeNil())
+			})
+		})
+	})
 })
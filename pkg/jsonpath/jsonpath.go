@@ -0,0 +1,103 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is returned when path does not point to any value
+// inside decoded JSON document.
+var ErrNotFound = errors.New("jsonpath: value not found")
+
+// Lookup decodes JSON data and returns value pointed by path.
+// Supported path syntax is a subset of JSONPath: root "$", dot
+// child ".key", bracket child "['key']" and array index "[n]",
+// e.g. $.items[0].name.
+func Lookup(data []byte, path string) (interface{}, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("jsonpath: invalid JSON document: %w", err)
+	}
+
+	steps, err := Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := doc
+	for _, step := range steps {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			val, ok := node[step]
+			if !ok {
+				return nil, ErrNotFound
+			}
+			current = val
+		case []interface{}:
+			idx, err := strconv.Atoi(step)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, ErrNotFound
+			}
+			current = node[idx]
+		default:
+			return nil, ErrNotFound
+		}
+	}
+	return current, nil
+}
+
+// Parse splits path into separate keys and indexes. It reports
+// an error if path does not start with "$" or is badly formed.
+func Parse(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("jsonpath: path %q must start with $", path)
+	}
+
+	var steps []string
+	rest := path[1:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("jsonpath: empty key in path %q", path)
+			}
+			steps = append(steps, rest[:end])
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("jsonpath: missing ] in path %q", path)
+			}
+			key := strings.Trim(rest[1:end], `'"`)
+			if key == "" {
+				return nil, fmt.Errorf("jsonpath: empty key in path %q", path)
+			}
+			steps = append(steps, key)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("jsonpath: unexpected character %q in path %q", rest[0], path)
+		}
+	}
+	return steps, nil
+}
+
+// String returns text representation of value returned by Lookup.
+// Strings are returned as is, other values are JSON encoded.
+func String(val interface{}) string {
+	if s, ok := val.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+	return string(b)
+}
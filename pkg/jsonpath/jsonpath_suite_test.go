@@ -0,0 +1,13 @@
+package jsonpath_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestJsonpath(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "JSONPath Suite")
+}
@@ -0,0 +1,62 @@
+package jsonpath_test
+
+import (
+	. "github.com/gobuzz/pkg/jsonpath"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testContent is an internal aggregate for creating tableTest slice.
+type testContent struct {
+	path   string
+	result string
+}
+
+var _ = Describe("When calling Lookup", func() {
+	var (
+		doc  []byte
+		data []testContent
+	)
+
+	BeforeEach(func() {
+		doc = []byte(`{"status":"ok","count":15,"up":true,"items":[{"name":"a"},{"name":"b"}],"meta":{"load avg":0.5}}`)
+		data = []testContent{
+			{path: "$.status", result: "ok"},
+			{path: "$.count", result: "15"},
+			{path: "$.up", result: "true"},
+			{path: "$.items[1].name", result: "b"},
+			{path: "$['meta']['load avg']", result: "0.5"},
+			{path: "$.items[0]", result: `{"name":"a"}`},
+		}
+	})
+
+	Context("When path points to existing value.", func() {
+		It("Should return value in text form.", func() {
+			for _, el := range data {
+				val, err := Lookup(doc, el.path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(String(val)).To(Equal(el.result))
+			}
+		})
+	})
+
+	Context("When path points to missing value.", func() {
+		It("Should return ErrNotFound.", func() {
+			for _, path := range []string{"$.missing", "$.items[5]", "$.status.len", "$.items.name"} {
+				_, err := Lookup(doc, path)
+				Expect(err).To(Equal(ErrNotFound))
+			}
+		})
+	})
+
+	Context("When path or document is malformed.", func() {
+		It("Should return an error.", func() {
+			for _, path := range []string{"status", "$..status", "$.items[0", "$x"} {
+				_, err := Lookup(doc, path)
+				Expect(err).To(HaveOccurred())
+			}
+			_, err := Lookup([]byte(`{"status":`), "$.status")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
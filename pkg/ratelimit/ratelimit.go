@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Rate defines token bucket refilled with PerSecond tokens each second
+// holding up to Burst tokens. Zero PerSecond means no limit.
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// bucket is a single token bucket state.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter keeps token bucket of each key. Buckets unused for longer
+// than it takes to refill them are dropped.
+type Limiter struct {
+	rate    Rate
+	buckets map[string]*bucket
+	sweep   time.Time // last time idle buckets were dropped
+	mu      sync.Mutex
+}
+
+// NewLimiter creates limiter with given rate.
+func NewLimiter(rate Rate) *Limiter {
+	if rate.Burst < 1 {
+		rate.Burst = 1
+	}
+	return &Limiter{rate: rate, buckets: make(map[string]*bucket)}
+}
+
+// Allow takes a token from bucket of key at now. If bucket is empty
+// it reports false and time after which a token will be available.
+func (l *Limiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	if l.rate.PerSecond <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	full := time.Duration(float64(l.rate.Burst) / l.rate.PerSecond * float64(time.Second))
+	if now.Sub(l.sweep) > full {
+		for k, b := range l.buckets {
+			if now.Sub(b.last) > full {
+				delete(l.buckets, k)
+			}
+		}
+		l.sweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.rate.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(l.rate.Burst), b.tokens+elapsed*l.rate.PerSecond)
+		b.last = now
+	}
+
+	if b.tokens < 1 {
+		wait := (1 - b.tokens) / l.rate.PerSecond
+		return false, time.Duration(wait * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
@@ -0,0 +1,65 @@
+package ratelimit_test
+
+import (
+	"time"
+
+	. "github.com/gobuzz/pkg/ratelimit"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Limiter", func() {
+	var (
+		limiter *Limiter
+		now     time.Time
+	)
+
+	BeforeEach(func() { // Configuration
+		limiter = NewLimiter(Rate{PerSecond: 2, Burst: 3})
+		now = time.Unix(1588334400, 0)
+	})
+
+	Context("When bucket is full.", func() {
+		It("Should allow burst and then report wait time.", func() {
+			for i := 0; i < 3; i++ {
+				ok, _ := limiter.Allow("a", now)
+				Expect(ok).To(BeTrue())
+			}
+			ok, wait := limiter.Allow("a", now)
+			Expect(ok).To(BeFalse())
+			Expect(wait).To(Equal(500 * time.Millisecond))
+		})
+	})
+
+	Context("When time passes.", func() {
+		It("Should refill bucket with rate.", func() {
+			for i := 0; i < 3; i++ {
+				limiter.Allow("a", now)
+			}
+			ok, _ := limiter.Allow("a", now.Add(500*time.Millisecond))
+			Expect(ok).To(BeTrue())
+			ok, _ = limiter.Allow("a", now.Add(500*time.Millisecond))
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("When keys differ.", func() {
+		It("Should keep separate buckets.", func() {
+			for i := 0; i < 3; i++ {
+				limiter.Allow("a", now)
+			}
+			ok, _ := limiter.Allow("b", now)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Context("When rate is zero.", func() {
+		It("Should allow everything.", func() {
+			limiter = NewLimiter(Rate{})
+			for i := 0; i < 100; i++ {
+				ok, _ := limiter.Allow("a", now)
+				Expect(ok).To(BeTrue())
+			}
+		})
+	})
+})
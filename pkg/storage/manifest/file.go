@@ -0,0 +1,121 @@
+// Package manifest reads fetch manifests from YAML files and watches
+// them for changes.
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/domain/reconciling"
+	"gopkg.in/yaml.v2"
+)
+
+// File is a YAML manifest file. Fetcher fields are those of fetch
+// creation payload plus tenant, e.g.
+//
+//	prune: true
+//	fetchers:
+//	  - name: front
+//	    tenant: team-a
+//	    url: https://httpbin.org/range/15
+//	    interval: 60
+//	    tags: [prod]
+type File struct {
+	Path string
+}
+
+// document is YAML manifest decoded through JSON, so fetcher fields
+// match JSON payload fields.
+type document struct {
+	Prune    bool      `json:"prune"`
+	Fetchers []fetcher `json:"fetchers"`
+}
+
+// fetcher is a single manifest fetch.
+type fetcher struct {
+	Tenant string `json:"tenant"`
+	adding.Fetch
+}
+
+// Manifest implements reconciling.RepositoryManifest interface. File
+// content which is not a valid manifest is reported as validation error.
+func (f File) Manifest() (reconciling.Manifest, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return reconciling.Manifest{}, fmt.Errorf("reading manifest: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes YAML manifest. Unknown fields are rejected.
+func Parse(data []byte) (reconciling.Manifest, error) {
+	js, err := ToJSON(data)
+	if err != nil {
+		return reconciling.Manifest{}, failure.Invalid("manifest", "Manifest is not valid: %s.", err)
+	}
+
+	var doc document
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil && string(js) != "null" { // empty file declares nothing
+		return reconciling.Manifest{}, failure.Invalid("manifest", "Manifest is not valid: %s.", strings.TrimPrefix(err.Error(), "json: "))
+	}
+
+	m := reconciling.Manifest{Prune: doc.Prune, Fetches: make([]adding.Fetch, len(doc.Fetchers))}
+	for i, f := range doc.Fetchers {
+		m.Fetches[i] = f.Fetch
+		m.Fetches[i].Tenant = f.Tenant
+	}
+	return m, nil
+}
+
+// ToJSON converts YAML document into JSON one, so it can be decoded
+// into structs with JSON field tags.
+func ToJSON(data []byte) ([]byte, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s", strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+	raw, err := jsonValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(raw)
+}
+
+// jsonValue converts decoded YAML value into one encodable as JSON.
+// YAML mappings may have non-string keys, JSON objects may not.
+func jsonValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for k, el := range v {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("key %v is not a string", k)
+			}
+			val, err := jsonValue(el)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = val
+		}
+		return obj, nil
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, el := range v {
+			val, err := jsonValue(el)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = val
+		}
+		return arr, nil
+	}
+	return v, nil
+}
@@ -0,0 +1,107 @@
+package manifest_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/domain/reconciling"
+	. "github.com/gobuzz/pkg/storage/manifest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const manifestYAML = `
+prune: true
+fetchers:
+  - name: front
+    url: https://httpbin.org/range/15
+    interval: 60
+    tags: [prod]
+    assertions:
+      - type: contains
+        value: abc
+  - name: slow
+    tenant: team-a
+    url: https://httpbin.org/delay/3
+    interval: 120
+    retention:
+      max_records: 10
+`
+
+var _ = Describe("The manifest file", func() {
+
+	Describe("When calling Parse", func() {
+		Context("When manifest is valid.", func() {
+			It("Should return declared fetches.", func() {
+				m, err := Parse([]byte(manifestYAML))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(m).To(Equal(reconciling.Manifest{Prune: true, Fetches: []adding.Fetch{
+					{Name: "front", URL: "https://httpbin.org/range/15", Interval: 60, Tags: []string{"prod"}, Assertions: []adding.Check{{Type: "contains", Value: "abc"}}},
+					{Name: "slow", Tenant: "team-a", URL: "https://httpbin.org/delay/3", Interval: 120, Retention: &adding.Retention{MaxRecords: 10}},
+				}}))
+			})
+		})
+
+		Context("When manifest is empty.", func() {
+			It("Should declare nothing.", func() {
+				m, err := Parse(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(m.Fetches).To(BeEmpty())
+			})
+		})
+
+		Context("When manifest is not valid.", func() {
+			It("Should report validation error.", func() {
+				_, err := Parse([]byte("fetchers:\n  - name: front\n    every: 60\n"))
+				Expect(err).To(Equal(&failure.Validation{Field: "manifest", Msg: `Manifest is not valid: unknown field "every".`}))
+
+				_, err = Parse([]byte("fetchers: [\n"))
+				Expect(err).To(BeAssignableToTypeOf(&failure.Validation{}))
+
+				_, err = Parse([]byte("fetchers:\n  - name: front\n    interval: often\n"))
+				Expect(err).To(BeAssignableToTypeOf(&failure.Validation{}))
+			})
+		})
+	})
+
+	Describe("When calling Watch", func() {
+		var (
+			dir     string
+			path    string
+			changed chan struct{}
+			stop    chan struct{}
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "manifest")
+			Expect(err).NotTo(HaveOccurred())
+			path = filepath.Join(dir, "fetchers.yaml")
+			Expect(os.WriteFile(path, []byte(manifestYAML), 0o600)).To(Succeed())
+
+			changed, stop = make(chan struct{}, 8), make(chan struct{})
+			go Watch(path, func() { changed <- struct{}{} }, stop)
+		})
+
+		AfterEach(func() {
+			close(stop)
+			os.RemoveAll(dir)
+		})
+
+		It("Should report writing and replacing the file only.", func() {
+			Consistently(changed, "300ms").ShouldNot(Receive())
+
+			Expect(os.WriteFile(path+".tmp", []byte(manifestYAML), 0o600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "other.yaml"), nil, 0o600)).To(Succeed())
+			Consistently(changed, "300ms").ShouldNot(Receive())
+
+			Expect(os.Rename(path+".tmp", path)).To(Succeed())
+			Eventually(changed, "2s").Should(Receive())
+
+			Expect(os.WriteFile(path, []byte("prune: false\n"), 0o600)).To(Succeed())
+			Eventually(changed, "2s").Should(Receive())
+		})
+	})
+})
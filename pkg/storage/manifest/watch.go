@@ -0,0 +1,53 @@
+package manifest
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// settle is the time to wait for more events of a single file save.
+// Editors often write, rename and chmod the file in a row.
+const settle = 200 * time.Millisecond
+
+// Watch calls changed after the file at path is written, created or
+// replaced, until stop is closed. Directory of the file is watched, so
+// changes done by renaming another file over it are seen too.
+func Watch(path string, changed func(), stop <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watching manifest: %w", err)
+	}
+	defer watcher.Close()
+
+	path = filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("watching manifest: %w", err)
+	}
+
+	var pending <-chan time.Time
+	for {
+		select {
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(ev.Name) == path && ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				pending = time.After(settle)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("Watching manifest failed: %v\n", err)
+		case <-pending:
+			pending = nil
+			changed()
+		case <-stop:
+			return nil
+		}
+	}
+}
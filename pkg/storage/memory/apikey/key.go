@@ -0,0 +1,14 @@
+package apikey
+
+import "time"
+
+// Internal map record struct for storing an API key
+type key struct {
+	id        string
+	name      string
+	tenant    string
+	scopes    []string
+	hash      [32]byte
+	createdAt time.Time
+	revoked   bool
+}
@@ -0,0 +1,127 @@
+package apikey
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gobuzz/pkg/domain/authenticating"
+)
+
+// Storage represetns internal storage of API keys. Keys are indexed
+// by ID and by secret hash.
+type Storage struct {
+	db     map[string]key
+	hashes map[[32]byte]string
+	mu     sync.RWMutex
+	init   sync.Once
+}
+
+func (s *Storage) initOnce() {
+	s.init.Do(func() {
+		s.db = make(map[string]key)
+		s.hashes = make(map[[32]byte]string)
+	})
+}
+
+// CreateKey adds API key into storage. Reports false if key with
+// the same ID or secret hash already exists.
+func (s *Storage) CreateKey(data authenticating.Key) bool {
+	s.initOnce()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.db[data.ID]; ok {
+		return false
+	}
+	if _, ok := s.hashes[data.Hash]; ok {
+		return false
+	}
+
+	s.db[data.ID] = fromDomain(data)
+	s.hashes[data.Hash] = data.ID
+	return true
+}
+
+// UpdateKey replaces stored API key with the same ID. Reports false
+// if there is no such key.
+func (s *Storage) UpdateKey(data authenticating.Key) bool {
+	s.initOnce()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.db[data.ID]
+	if !ok {
+		return false
+	}
+
+	delete(s.hashes, old.hash)
+	s.db[data.ID] = fromDomain(data)
+	s.hashes[data.Hash] = data.ID
+	return true
+}
+
+// Key returns API key with given ID.
+func (s *Storage) Key(id string) (authenticating.Key, bool) {
+	s.initOnce()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	k, ok := s.db[id]
+	return k.toDomain(), ok
+}
+
+// KeyByHash returns API key with given secret hash.
+func (s *Storage) KeyByHash(hash [32]byte) (authenticating.Key, bool) {
+	s.initOnce()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.hashes[hash]
+	if !ok {
+		return authenticating.Key{}, false
+	}
+	return s.db[id].toDomain(), true
+}
+
+// Keys returns all stored API keys.
+func (s *Storage) Keys() []authenticating.Key {
+	s.initOnce()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]authenticating.Key, 0, len(s.db))
+	for _, k := range s.db {
+		keys = append(keys, k.toDomain())
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.Before(keys[j].CreatedAt) })
+	return keys
+}
+
+func fromDomain(k authenticating.Key) key {
+	return key{
+		id:        k.ID,
+		name:      k.Name,
+		tenant:    k.Tenant,
+		scopes:    append([]string(nil), k.Scopes...),
+		hash:      k.Hash,
+		createdAt: k.CreatedAt,
+		revoked:   k.Revoked,
+	}
+}
+
+func (k key) toDomain() authenticating.Key {
+	return authenticating.Key{
+		ID:        k.id,
+		Name:      k.name,
+		Tenant:    k.tenant,
+		Scopes:    append([]string(nil), k.scopes...),
+		Hash:      k.hash,
+		CreatedAt: k.createdAt,
+		Revoked:   k.revoked,
+	}
+}
@@ -1,8 +1,61 @@
 package fetch
 
+import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/listing"
+)
+
 // Fetch defines map record struct for storing fetch request
 type fetch struct {
-	id       int
-	url      string
-	interval int
+	id         string
+	seq        int
+	tenant     string
+	name       string
+	url        string
+	interval   int
+	assertions []adding.Check
+	extractors []adding.Extractor
+	retention  *adding.Retention
+	tags       []string
+	paused     bool
+	until      time.Time // of pause, zero until resumed
+	createdAt  time.Time
+}
+
+// Internal map key of fetch record. IDs are globally unique, tenant
+// keeps fetches of other tenants out of reach. Record slice keeps fetch
+// definitions in order of updates, the last one is the current.
+type key struct {
+	tenant string
+	id     string
+}
+
+// toListing converts fetch record into listing one. Pause which ended
+// is not reported.
+func (f fetch) toListing() listing.Fetch {
+	fetch := listing.Fetch{
+		ID:         f.id,
+		Seq:        f.seq,
+		Name:       f.name,
+		URL:        f.url,
+		Interval:   f.interval,
+		Assertions: f.assertions,
+		Extractors: f.extractors,
+		Retention:  f.retention,
+		Tags:       f.tags,
+		Paused:     f.pausedNow(),
+		CreatedAt:  f.createdAt,
+	}
+	if fetch.Paused && !f.until.IsZero() {
+		until := f.until
+		fetch.PausedUntil = &until
+	}
+	return fetch
+}
+
+// pausedNow reports whether fetch is paused and its pause has not ended.
+func (f fetch) pausedNow() bool {
+	return f.paused && (f.until.IsZero() || time.Now().Before(f.until))
 }
@@ -2,37 +2,364 @@ package fetch
 
 import (
 	"fmt"
-	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/ulid"
 )
 
-// Storage represetns global storage for posted fetches
+// Storage represetns global storage for posted fetches. Fetch IDs are
+// ULIDs, each tenant keeps IDs of its fetches in creation sequence.
 type Storage struct {
-	uid  int
-	db   map[int][]fetch
+	ids  map[string][]string
+	db   map[key][]fetch
+	mu   sync.RWMutex
 	init sync.Once // for mutual exlcusion of critical section
 }
 
+func (f *Storage) initOnce() {
+	f.init.Do(func() {
+		f.db = make(map[key][]fetch)
+		f.ids = make(map[string][]string)
+	})
+}
+
 // CreateRecord returns an request ID after adding fetch into map storage.
-func (f *Storage) CreateRecord(data adding.Fetch) adding.ServiceValidation {
+// Fails with conflict error if fetch name is used by other tenant fetch.
+func (f *Storage) CreateRecord(data adding.Fetch) (string, error) {
 
 	// Init once
-	f.init.Do(func() {
-		f.db = make(map[int][]fetch)
-		f.uid = 0
-	})
+	f.initOnce()
 
-	fetchID := f.uid
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.find(data.Tenant, data.Name); ok {
+		return "", nameUsed(data.Name)
+	}
+
+	fetchID := ulid.New()
 	record := fetch{
-		id:       fetchID,
-		url:      data.URL,
-		interval: data.Interval,
+		id:         fetchID,
+		seq:        len(f.ids[data.Tenant]),
+		tenant:     data.Tenant,
+		name:       data.Name,
+		url:        data.URL,
+		interval:   data.Interval,
+		assertions: data.Assertions,
+		extractors: data.Extractors,
+		retention:  data.Retention,
+		tags:       data.Tags,
+		createdAt:  time.Now(),
+	}
+
+	k := key{tenant: data.Tenant, id: fetchID}
+	f.db[k] = append(f.db[k], record)
+	f.ids[data.Tenant] = append(f.ids[data.Tenant], fetchID)
+	return fetchID, nil
+}
+
+// UpdateRecord appends new definition of tenant fetch with given ID.
+// Creation time of the fetch is kept. Fails with not found error if
+// there is no such fetch and with conflict error if fetch name is used
+// by other tenant fetch.
+func (f *Storage) UpdateRecord(tenant, id string, data adding.Fetch) error {
+	f.initOnce()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	k := key{tenant: tenant, id: id}
+	records := f.db[k]
+	if len(records) == 0 {
+		return failure.Missing("Fetch %q not found.", id)
+	}
+	if found, ok := f.find(tenant, data.Name); ok && found != id {
+		return nameUsed(data.Name)
+	}
+
+	f.db[k] = append(records, fetch{
+		id:         id,
+		seq:        records[0].seq,
+		tenant:     tenant,
+		name:       data.Name,
+		url:        data.URL,
+		interval:   data.Interval,
+		assertions: data.Assertions,
+		extractors: data.Extractors,
+		retention:  data.Retention,
+		tags:       data.Tags,
+		paused:     records[len(records)-1].paused,
+		until:      records[len(records)-1].until,
+		createdAt:  records[0].createdAt,
+	})
+	return nil
+}
+
+// DeleteRecord removes tenant fetch with given ID. Its ID is not reused,
+// stored responses are left to compaction. Returns false if there is
+// no such fetch.
+func (f *Storage) DeleteRecord(tenant, id string) bool {
+	f.initOnce()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	k := key{tenant: tenant, id: id}
+	if _, ok := f.db[k]; !ok {
+		return false
+	}
+	delete(f.db, k)
+	return true
+}
+
+// PauseRecord marks tenant fetch with given ID paused until given time,
+// zero until it is resumed. Returns false if there is no such fetch.
+func (f *Storage) PauseRecord(tenant, id string, until time.Time) bool {
+	return f.setPaused(tenant, id, true, until)
+}
+
+// ResumeRecord clears pause of tenant fetch with given ID. Returns false
+// if there is no such fetch.
+func (f *Storage) ResumeRecord(tenant, id string) bool {
+	return f.setPaused(tenant, id, false, time.Time{})
+}
+
+// setPaused sets pause of the current definition of tenant fetch.
+func (f *Storage) setPaused(tenant, id string, paused bool, until time.Time) bool {
+	f.initOnce()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records := f.db[key{tenant: tenant, id: id}]
+	if len(records) == 0 {
+		return false
+	}
+	records[len(records)-1].paused = paused
+	records[len(records)-1].until = until
+	return true
+}
+
+// FindRecord returns ID of tenant fetch with given name.
+func (f *Storage) FindRecord(tenant, name string) (string, bool) {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.find(tenant, name)
+}
+
+// find returns ID of tenant fetch with given name, unnamed fetches are
+// never found. Must be called with f.mu held.
+func (f *Storage) find(tenant, name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	for k, records := range f.db {
+		if k.tenant == tenant && records[len(records)-1].name == name {
+			return k.id, true
+		}
+	}
+	return "", false
+}
+
+// MatchRecord returns ID of the oldest tenant fetch which URL has given
+// normalised form.
+func (f *Storage) MatchRecord(tenant, url string) (string, bool) {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for _, id := range f.ids[tenant] {
+		records := f.db[key{tenant: tenant, id: id}]
+		if n := len(records); n > 0 && adding.NormalURL(records[n-1].url) == url {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// nameUsed returns conflict error of fetch name used by other fetch.
+func nameUsed(name string) error {
+	return &failure.Conflict{Msg: fmt.Sprintf("Fetch name %q is already used.", name)}
+}
+
+// CountRecords returns number of fetches created by tenant.
+func (f *Storage) CountRecords(tenant string) int {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	count := 0
+	for k := range f.db {
+		if k.tenant == tenant {
+			count++
+		}
+	}
+	return count
+}
+
+// RecordInterval returns interval of tenant fetch with given ID. Reports
+// false if there is no such fetch.
+func (f *Storage) RecordInterval(tenant, id string) (int, bool) {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	records := f.db[key{tenant: tenant, id: id}]
+	if len(records) == 0 {
+		return 0, false
+	}
+	return records[len(records)-1].interval, true
+}
+
+// RecordPaused reports whether tenant fetch with given ID is paused and
+// its pause has not ended. Reports false as second value if there is no
+// such fetch.
+func (f *Storage) RecordPaused(tenant, id string) (bool, bool) {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	records := f.db[key{tenant: tenant, id: id}]
+	if len(records) == 0 {
+		return false, false
+	}
+	return records[len(records)-1].pausedNow(), true
+}
+
+// Load returns number of running fetches of all tenants and number of
+// fetches per second they do in total. Paused fetches are not counted
+// until their pause ends, deleted ones are gone with their Gophers.
+func (f *Storage) Load() (int, float64) {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	active, rate := 0, 0.0
+	for _, records := range f.db {
+		n := len(records)
+		if n == 0 || records[n-1].pausedNow() {
+			continue
+		}
+		active++
+		if records[n-1].interval > 0 {
+			rate += 1 / float64(records[n-1].interval)
+		}
 	}
+	return active, rate
+}
 
-	f.db[fetchID] = append(f.db[fetchID], record)
-	fmt.Println(f.db) // temp for content check
-	f.uid++
-	return adding.ServiceValidation{StorageKeyID: fetchID, Status: http.StatusOK, Msg: "Record has been insert into fetch db."}
+// Retentions returns retention rules of each fetch which has them set,
+// grouped by tenant.
+func (f *Storage) Retentions() map[string]map[string]adding.Retention {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	retentions := make(map[string]map[string]adding.Retention)
+	for k, records := range f.db {
+		if n := len(records); n > 0 && records[n-1].retention != nil {
+			if retentions[k.tenant] == nil {
+				retentions[k.tenant] = make(map[string]adding.Retention)
+			}
+			retentions[k.tenant][k.id] = *records[n-1].retention
+		}
+	}
+	return retentions
+}
+
+// All returns current fetches of all tenants grouped by tenant.
+func (f *Storage) All() map[string][]listing.Fetch {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	fetches := make(map[string][]listing.Fetch)
+	for k, records := range f.db {
+		if n := len(records); n > 0 {
+			fetches[k.tenant] = append(fetches[k.tenant], records[n-1].toListing())
+		}
+	}
+	return fetches
+}
+
+// Fetch returns tenant fetch with given ID.
+func (f *Storage) Fetch(tenant, id string) (listing.Fetch, bool) {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	records := f.db[key{tenant: tenant, id: id}]
+	if len(records) == 0 {
+		return listing.Fetch{}, false
+	}
+	return records[len(records)-1].toListing(), true
+}
+
+// Fetches returns at most q.Limit tenant fetches matching q in creation
+// order starting after q.After. Fetches are visited by creation
+// sequence from the cursor on and visiting stops at time range end.
+func (f *Storage) Fetches(tenant string, q listing.FetchQuery) []listing.Fetch {
+	f.initOnce()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ids := f.ids[tenant]
+	seq, step := 0, 1
+	if q.Desc {
+		seq, step = len(ids)-1, -1
+	}
+	if q.After != nil {
+		seq = q.After.Seq + step
+	}
+
+	fetches := []listing.Fetch{}
+	for ; seq >= 0 && seq < len(ids) && len(fetches) < q.Limit; seq += step {
+		records := f.db[key{tenant: tenant, id: ids[seq]}]
+		if len(records) == 0 {
+			continue
+		}
+		r := records[len(records)-1]
+
+		switch {
+		case !q.Desc && !q.To.IsZero() && r.createdAt.After(q.To):
+			return fetches
+		case q.Desc && !q.From.IsZero() && r.createdAt.Before(q.From):
+			return fetches
+		case !q.From.IsZero() && r.createdAt.Before(q.From),
+			!q.To.IsZero() && r.createdAt.After(q.To),
+			!strings.Contains(r.url, q.URL),
+			q.Tag != "" && !hasTag(r.tags, q.Tag):
+			continue
+		}
+
+		fetches = append(fetches, r.toListing())
+	}
+	return fetches
+}
+
+// hasTag reports whether tags contain tag.
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
 }
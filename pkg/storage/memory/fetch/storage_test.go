@@ -0,0 +1,150 @@
+package fetch_test
+
+import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/failure"
+	"github.com/gobuzz/pkg/domain/listing"
+	. "github.com/gobuzz/pkg/storage/memory/fetch"
+	"github.com/gobuzz/pkg/ulid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fetch storage", func() {
+	var storage *Storage
+
+	BeforeEach(func() { // Configuration
+		storage = new(Storage)
+	})
+
+	Describe("When creating fetches", func() {
+		It("Should assign globally unique IDs in creation order.", func() {
+			a, err := storage.CreateRecord(adding.Fetch{Tenant: "team-a", URL: "https://httpbin.org/range/15", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+			b, err := storage.CreateRecord(adding.Fetch{Tenant: "team-b", URL: "https://httpbin.org/range/15", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+			c, err := storage.CreateRecord(adding.Fetch{Tenant: "team-a", URL: "https://httpbin.org/range/16", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ulid.Valid(a)).To(BeTrue())
+			Expect(a).NotTo(Equal(b))
+			Expect(a < c).To(BeTrue())
+
+			_, ok := storage.Fetch("team-b", a)
+			Expect(ok).To(BeFalse())
+
+			all := listing.FetchQuery{Page: listing.Page{Limit: listing.MaxLimit}}
+			fetches := storage.Fetches("team-a", all)
+			Expect(fetches).To(HaveLen(2))
+			Expect([]string{fetches[0].ID, fetches[1].ID}).To(Equal([]string{a, c}))
+
+			all.After = &listing.Position{Seq: fetches[0].Seq}
+			Expect(storage.Fetches("team-a", all)).To(HaveLen(1))
+		})
+	})
+
+	Describe("When naming fetches", func() {
+		var front string
+
+		BeforeEach(func() {
+			var err error
+			front, err = storage.CreateRecord(adding.Fetch{Name: "front", URL: "https://httpbin.org/range/15", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should find fetch by name within its tenant.", func() {
+			id, ok := storage.FindRecord("", "front")
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal(front))
+
+			_, ok = storage.FindRecord("team-a", "front")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("Should reject name used by other fetch of the tenant.", func() {
+			conflict := &failure.Conflict{Msg: `Fetch name "front" is already used.`}
+			_, err := storage.CreateRecord(adding.Fetch{Name: "front", URL: "https://httpbin.org/range/16", Interval: 60})
+			Expect(err).To(Equal(conflict))
+
+			other, err := storage.CreateRecord(adding.Fetch{Name: "back", URL: "https://httpbin.org/range/16", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(storage.UpdateRecord("", other, adding.Fetch{Name: "front", URL: "https://httpbin.org/range/16", Interval: 60})).To(Equal(conflict))
+			Expect(storage.UpdateRecord("", front, adding.Fetch{Name: "front", URL: "https://httpbin.org/range/16", Interval: 30})).To(Succeed())
+			interval, ok := storage.RecordInterval("", front)
+			Expect(ok).To(BeTrue())
+			Expect(interval).To(Equal(30))
+			_, ok = storage.RecordInterval("team-a", front)
+			Expect(ok).To(BeFalse())
+
+			_, err = storage.CreateRecord(adding.Fetch{Tenant: "team-a", Name: "front", URL: "https://httpbin.org/range/16", Interval: 60})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should report missing fetch on update.", func() {
+			err := storage.UpdateRecord("team-a", front, adding.Fetch{URL: "https://httpbin.org/range/16", Interval: 60})
+			Expect(err).To(Equal(&failure.NotFound{Msg: `Fetch "` + front + `" not found.`}))
+		})
+	})
+
+	Describe("When pausing fetches", func() {
+		It("Should keep pause over updates until resumed or ended.", func() {
+			id, _ := storage.CreateRecord(adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 60})
+			Expect(storage.PauseRecord("", id, time.Time{})).To(BeTrue())
+			Expect(storage.UpdateRecord("", id, adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 30})).To(Succeed())
+			f, _ := storage.Fetch("", id)
+			Expect(f.Paused).To(BeTrue())
+			Expect(f.PausedUntil).To(BeNil())
+
+			paused, ok := storage.RecordPaused("", id)
+			Expect(paused).To(BeTrue())
+			Expect(ok).To(BeTrue())
+			Expect(storage.ResumeRecord("", id)).To(BeTrue())
+			f, _ = storage.Fetch("", id)
+			Expect(f.Paused).To(BeFalse())
+
+			Expect(storage.PauseRecord("", id, time.Now().Add(-time.Second))).To(BeTrue())
+			f, _ = storage.Fetch("", id)
+			Expect(f.Paused).To(BeFalse())
+			Expect(f.PausedUntil).To(BeNil())
+
+			Expect(storage.PauseRecord("team-a", id, time.Time{})).To(BeFalse())
+		})
+
+		It("Should not count paused fetches into load.", func() {
+			a, _ := storage.CreateRecord(adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 2})
+			b, _ := storage.CreateRecord(adding.Fetch{URL: "https://httpbin.org/range/16", Interval: 4})
+			Expect(storage.PauseRecord("", a, time.Time{})).To(BeTrue())
+			Expect(storage.PauseRecord("", b, time.Now().Add(-time.Second))).To(BeTrue())
+			active, rate := storage.Load()
+			Expect(active).To(Equal(1))
+			Expect(rate).To(Equal(0.25))
+
+			Expect(storage.ResumeRecord("", a)).To(BeTrue())
+			active, rate = storage.Load()
+			Expect(active).To(Equal(2))
+			Expect(rate).To(Equal(0.75))
+		})
+	})
+
+	Describe("When matching fetches by URL", func() {
+		It("Should return the oldest current fetch of the tenant.", func() {
+			a, _ := storage.CreateRecord(adding.Fetch{URL: "https://httpbin.org/range/15", Interval: 60})
+			b, _ := storage.CreateRecord(adding.Fetch{URL: "https://HTTPBIN.org:443/range/15", Interval: 30})
+			storage.CreateRecord(adding.Fetch{Tenant: "team-a", URL: "https://httpbin.org/range/16", Interval: 60})
+
+			id, ok := storage.MatchRecord("", adding.NormalURL("https://httpbin.org/range/15"))
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal(a))
+
+			Expect(storage.UpdateRecord("", a, adding.Fetch{URL: "https://httpbin.org/range/20", Interval: 60})).To(Succeed())
+			id, ok = storage.MatchRecord("", adding.NormalURL("https://httpbin.org/range/15"))
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal(b))
+
+			_, ok = storage.MatchRecord("", adding.NormalURL("https://httpbin.org/range/16"))
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
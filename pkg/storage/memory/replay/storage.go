@@ -0,0 +1,97 @@
+package replay
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/replaying"
+)
+
+type recordKey struct {
+	tenant, key string
+}
+
+// entry is storage queue element, records expire in reservation order.
+type entry struct {
+	key       recordKey
+	createdAt time.Time
+}
+
+// Storage represetns internal storage of idempotency records. Expired
+// records are dropped while reserving new ones.
+type Storage struct {
+	db    map[recordKey]replaying.Record
+	queue []entry
+	mu    sync.Mutex
+	init  sync.Once
+}
+
+func (s *Storage) initOnce() {
+	s.init.Do(func() {
+		s.db = make(map[recordKey]replaying.Record)
+	})
+}
+
+// ReserveRecord stores record unless the same key of the tenant has
+// record created since given time. The existing record is returned then.
+func (s *Storage) ReserveRecord(r replaying.Record, since time.Time) (replaying.Record, bool) {
+	s.initOnce()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(since)
+	k := recordKey{r.Tenant, r.Key}
+	if old, ok := s.db[k]; ok {
+		return old, false
+	}
+	s.db[k] = r
+	s.queue = append(s.queue, entry{key: k, createdAt: r.CreatedAt})
+	return r, true
+}
+
+// CompleteRecord sets ID of fetch created or found by request holding
+// the key. Reports false if there is no such record.
+func (s *Storage) CompleteRecord(tenant, key, id string, found bool) bool {
+	s.initOnce()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := recordKey{tenant, key}
+	r, ok := s.db[k]
+	if !ok {
+		return false
+	}
+	r.ID, r.Found = id, found
+	s.db[k] = r
+	return true
+}
+
+// DeleteRecord removes record of the key. Reports false if there is no
+// such record.
+func (s *Storage) DeleteRecord(tenant, key string) bool {
+	s.initOnce()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := recordKey{tenant, key}
+	_, ok := s.db[k]
+	delete(s.db, k)
+	return ok
+}
+
+// expire drops records created before since. Queue may hold entries of
+// deleted or reserved again keys, they are skipped. Must be called
+// under the lock.
+func (s *Storage) expire(since time.Time) {
+	n := 0
+	for ; n < len(s.queue) && s.queue[n].createdAt.Before(since); n++ {
+		e := s.queue[n]
+		if r, ok := s.db[e.key]; ok && r.CreatedAt.Equal(e.createdAt) {
+			delete(s.db, e.key)
+		}
+	}
+	s.queue = s.queue[n:]
+}
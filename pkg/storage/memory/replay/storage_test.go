@@ -0,0 +1,60 @@
+package replay_test
+
+import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/replaying"
+	. "github.com/gobuzz/pkg/storage/memory/replay"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Replay storage", func() {
+	var (
+		storage *Storage
+		start   time.Time
+	)
+
+	BeforeEach(func() { // Configuration
+		storage = new(Storage)
+		start = time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	})
+
+	record := func(tenant, key string, at time.Time) replaying.Record {
+		return replaying.Record{Tenant: tenant, Key: key, Hash: [32]byte{1}, CreatedAt: at}
+	}
+
+	Describe("When reserving keys", func() {
+		It("Should return completed record of used key.", func() {
+			_, ok := storage.ReserveRecord(record("default", "k1", start), start.Add(-time.Hour))
+			Expect(ok).To(BeTrue())
+			Expect(storage.CompleteRecord("default", "k1", "01HZX3V6Q8J5K2M9N4P7R1S3T5", true)).To(BeTrue())
+
+			old, ok := storage.ReserveRecord(record("default", "k1", start.Add(time.Minute)), start.Add(-time.Hour))
+			Expect(ok).To(BeFalse())
+			Expect(old.ID).To(Equal("01HZX3V6Q8J5K2M9N4P7R1S3T5"))
+			Expect(old.Found).To(BeTrue())
+			Expect(old.CreatedAt).To(Equal(start))
+
+			_, ok = storage.ReserveRecord(record("team-a", "k1", start), start.Add(-time.Hour))
+			Expect(ok).To(BeTrue())
+		})
+
+		It("Should reserve again expired or deleted keys.", func() {
+			storage.ReserveRecord(record("default", "k1", start), start.Add(-time.Hour))
+			storage.ReserveRecord(record("default", "k2", start.Add(time.Minute)), start.Add(-time.Hour))
+			Expect(storage.DeleteRecord("default", "k2")).To(BeTrue())
+			Expect(storage.DeleteRecord("default", "k2")).To(BeFalse())
+
+			_, ok := storage.ReserveRecord(record("default", "k2", start.Add(2*time.Minute)), start.Add(-time.Hour))
+			Expect(ok).To(BeTrue())
+
+			later := start.Add(time.Hour + time.Second)
+			_, ok = storage.ReserveRecord(record("default", "k1", later), later.Add(-time.Hour))
+			Expect(ok).To(BeTrue())
+			_, ok = storage.ReserveRecord(record("default", "k2", later), later.Add(-time.Hour))
+			Expect(ok).To(BeFalse())
+			Expect(storage.CompleteRecord("default", "missing", "01HZX3V6Q8J5K2M9N4P7R1S3T5", false)).To(BeFalse())
+		})
+	})
+})
@@ -1,12 +1,45 @@
 package memory
 
 import (
+	"time"
+
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/storage/memory/apikey"
 	"github.com/gobuzz/pkg/storage/memory/fetch"
+	"github.com/gobuzz/pkg/storage/memory/replay"
 	"github.com/gobuzz/pkg/storage/memory/response"
+	"github.com/gobuzz/pkg/storage/memory/tenant"
 )
 
-// ResponseFetch is an aggregate which keeps fetch and response data in memory
+// ResponseFetch is an aggregate which keeps fetch, response, API key,
+// tenant quota and idempotency data in memory
 type ResponseFetch struct {
 	Fetches   fetch.Storage
 	Responses response.Storage
+	Keys      apikey.Storage
+	Tenants   tenant.Storage
+	Replays   replay.Storage
+}
+
+// Compact implements compacting.RepositoryCompactor interface. Retention
+// set on fetch takes precedence over global MaxRecords and MaxAge rules,
+// global MaxBytes caps size of all stored responses.
+func (rf *ResponseFetch) Compact(global compacting.Policy, now time.Time) compacting.Report {
+	retentions := rf.Fetches.Retentions()
+	policy := func(tenant, id string) compacting.Policy {
+		pol := compacting.Policy{MaxRecords: global.MaxRecords, MaxAge: global.MaxAge}
+		r, ok := retentions[tenant][id]
+		if !ok {
+			return pol
+		}
+		if r.MaxRecords > 0 {
+			pol.MaxRecords = r.MaxRecords
+		}
+		if r.MaxAge > 0 {
+			pol.MaxAge = time.Duration(r.MaxAge) * time.Second
+		}
+		pol.MaxBytes = r.MaxBytes
+		return pol
+	}
+	return rf.Responses.Compact(policy, global, now)
 }
@@ -0,0 +1,83 @@
+package response
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Supported body compression codecs.
+const (
+	Gzip  = "gzip" // default
+	Zstd  = "zstd"
+	Plain = "none"
+)
+
+var (
+	gzipWriters = sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}
+
+	zstdInit    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+)
+
+// zstdCodec returns encoder and decoder shared by all storages.
+// Both are safe for concurrent use with EncodeAll and DecodeAll.
+func zstdCodec() (*zstd.Encoder, *zstd.Decoder) {
+	zstdInit.Do(func() {
+		zstdEncoder, _ = zstd.NewWriter(nil)
+		zstdDecoder, _ = zstd.NewReader(nil)
+	})
+	return zstdEncoder, zstdDecoder
+}
+
+// compress returns data compressed with given codec. Returned slice
+// capacity is trimmed to its length as it is kept in storage.
+func compress(codec string, data []byte) ([]byte, error) {
+	var out []byte
+	switch codec {
+	case Plain:
+		out = data
+	case Zstd:
+		enc, _ := zstdCodec()
+		out = enc.EncodeAll(data, nil)
+	case Gzip, "":
+		var buf bytes.Buffer
+		zw := gzipWriters.Get().(*gzip.Writer)
+		defer gzipWriters.Put(zw)
+		zw.Reset(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		out = buf.Bytes()
+	default:
+		return nil, fmt.Errorf("unknown codec %q", codec)
+	}
+	return append([]byte(nil), out...), nil
+}
+
+// decompress returns data decompressed with given codec.
+func decompress(codec string, data []byte) ([]byte, error) {
+	switch codec {
+	case Plain:
+		return data, nil
+	case Zstd:
+		_, dec := zstdCodec()
+		return dec.DecodeAll(data, nil)
+	case Gzip, "":
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return ioutil.ReadAll(zr)
+	}
+	return nil, fmt.Errorf("unknown codec %q", codec)
+}
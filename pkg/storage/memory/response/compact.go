@@ -0,0 +1,197 @@
+package response
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/compacting"
+)
+
+// Compact removes records and time series points violating retention
+// policy of each fetch returned by policy func and then the oldest
+// records until global MaxBytes cap is met. Bodies no longer referenced
+// by any record are released. Reported bytes are compressed body bytes.
+func (s *Storage) Compact(policy func(tenant, id string) compacting.Policy, global compacting.Policy, now time.Time) compacting.Report {
+	s.initOnce()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := compacting.Report{LastRun: now, DedupedBodies: s.deduped}
+	before := s.storedBytes()
+	for k, records := range s.db {
+		pol := policy(k.tenant, k.id)
+		drop := 0
+		if pol.MaxAge > 0 {
+			for drop < len(records) && now.Sub(records[drop].time()) > pol.MaxAge {
+				drop++
+			}
+		}
+		if pol.MaxRecords > 0 && len(records)-drop > pol.MaxRecords {
+			drop = len(records) - pol.MaxRecords
+		}
+		s.release(records[:drop])
+		s.db[k] = trim(records, drop)
+		report.RemovedRecords += drop
+
+		if pol.MaxBytes > 0 {
+			report.RemovedRecords += s.trimBytes(k, pol.MaxBytes)
+		}
+	}
+
+	for k, series := range s.series {
+		pol := policy(k.tenant, k.id)
+		for name, points := range series {
+			drop := 0
+			if pol.MaxAge > 0 {
+				for drop < len(points) && now.Sub(points[drop].at) > pol.MaxAge {
+					drop++
+				}
+			}
+			if pol.MaxRecords > 0 && len(points)-drop > pol.MaxRecords {
+				drop = len(points) - pol.MaxRecords
+			}
+			if drop == len(points) {
+				delete(series, name)
+			} else if drop > 0 {
+				series[name] = append([]point(nil), points[drop:]...)
+			}
+			report.RemovedPoints += drop
+		}
+		if len(series) == 0 {
+			delete(s.series, k)
+		}
+	}
+
+	if global.MaxBytes > 0 {
+		report.RemovedRecords += s.trimGlobalBytes(global.MaxBytes)
+	}
+
+	report.StoredBytes = s.storedBytes()
+	report.ReclaimedBytes = before - report.StoredBytes + s.dedupSaved
+	s.deduped, s.dedupSaved = 0, 0
+	return report
+}
+
+// trimBytes removes the oldest records of fetch with given key until
+// bodies referenced by fetch fit into limit. Returns number of removed
+// records.
+func (s *Storage) trimBytes(k key, limit int) int {
+	records := s.db[k]
+	refs := make(map[[32]byte]int)
+	total := 0
+	for _, r := range records {
+		if refs[r.body] == 0 {
+			total += len(s.blobs[r.body].data)
+		}
+		refs[r.body]++
+	}
+
+	drop := 0
+	for drop < len(records) && total > limit {
+		body := records[drop].body
+		if refs[body]--; refs[body] == 0 {
+			total -= len(s.blobs[body].data)
+		}
+		drop++
+	}
+	s.release(records[:drop])
+	s.db[k] = trim(records, drop)
+	return drop
+}
+
+// trimGlobalBytes removes the oldest records across all fetches until
+// stored bodies fit into limit. Returns number of removed records.
+func (s *Storage) trimGlobalBytes(limit int) int {
+	total := s.storedBytes()
+	if total <= limit {
+		return 0
+	}
+
+	h := make(oldestHeap, 0, len(s.db))
+	for k, records := range s.db {
+		if len(records) > 0 {
+			h = append(h, &oldest{k: k, records: records})
+		}
+	}
+	fetches := append([]*oldest(nil), h...)
+	heap.Init(&h)
+
+	removed := 0
+	for total > limit && h.Len() > 0 {
+		o := h[0]
+		total -= s.release(o.records[o.drop : o.drop+1])
+		o.drop++
+		removed++
+		if o.drop == len(o.records) {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+
+	for _, o := range fetches {
+		if o.drop > 0 {
+			s.db[o.k] = trim(o.records, o.drop)
+		}
+	}
+	return removed
+}
+
+// oldest is a fetch with records of which the first drop ones are
+// removed.
+type oldest struct {
+	k       key
+	records []response
+	drop    int
+}
+
+// oldestHeap orders fetches by time of their oldest record kept.
+type oldestHeap []*oldest
+
+func (h oldestHeap) Len() int { return len(h) }
+func (h oldestHeap) Less(i, j int) bool {
+	return h[i].records[h[i].drop].time().Before(h[j].records[h[j].drop].time())
+}
+func (h oldestHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *oldestHeap) Push(x interface{}) { *h = append(*h, x.(*oldest)) }
+func (h *oldestHeap) Pop() interface{} {
+	old := *h
+	o := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return o
+}
+
+// release drops body references of records and removes bodies which
+// are no longer referenced. Returns number of released bytes.
+func (s *Storage) release(records []response) int {
+	released := 0
+	for _, r := range records {
+		b := s.blobs[r.body]
+		if b.refs--; b.refs == 0 {
+			released += len(b.data)
+			delete(s.blobs, r.body)
+		}
+	}
+	return released
+}
+
+// storedBytes returns number of stored body bytes.
+func (s *Storage) storedBytes() int {
+	total := 0
+	for _, b := range s.blobs {
+		total += len(b.data)
+	}
+	return total
+}
+
+// trim removes first n records.
+func trim(records []response, n int) []response {
+	if n == 0 {
+		return records
+	}
+
+	kept := make([]response, len(records)-n)
+	copy(kept, records[n:])
+	return kept
+}
@@ -1,8 +1,62 @@
 package response
 
-// Internal map record struct for storing a request
+import (
+	"strconv"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/adding"
+	"github.com/gobuzz/pkg/domain/responding"
+)
+
+// Internal map record struct for storing a request. Seq grows with
+// creation time across all fetches.
 type response struct {
-	response  string
-	duration  float64
-	createdAt string
+	seq        int
+	body       [32]byte // sha256 key of response body in blob storage
+	binary     bool
+	mediaType  string
+	duration   float64
+	delay      float64
+	createdAt  string
+	outcome    string
+	err        string
+	manual     bool
+	assertions []responding.CheckResult
+}
+
+// time returns record creation time.
+func (r *response) time() time.Time {
+	sec, _ := strconv.ParseFloat(r.createdAt, 64)
+	return time.Unix(0, int64(sec*float64(time.Second)))
+}
+
+// Internal map key of fetch responses.
+type key struct {
+	tenant string
+	id     string
+}
+
+// Internal content addressable record struct for storing compressed
+// response body shared by all records with the same content.
+type blob struct {
+	data  []byte
+	codec string
+	refs  int
+}
+
+// Internal time series record struct for storing extracted value
+type point struct {
+	at     time.Time
+	sample responding.Sample
+}
+
+// value returns typed value of the point.
+func (p point) value() interface{} {
+	switch p.sample.Type {
+	case adding.ValueString:
+		return p.sample.Text
+	case adding.ValueBool:
+		return p.sample.Bool
+	}
+	return p.sample.Number
 }
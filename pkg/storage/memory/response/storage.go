@@ -1,40 +1,276 @@
 package response
 
 import (
+	"crypto/sha256"
 	"fmt"
-	"net/http"
+	"log"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/gobuzz/pkg/domain/listing"
 	"github.com/gobuzz/pkg/domain/responding"
 	"github.com/levenlabs/golib/timeutil"
 )
 
-// Storage represetns internal storage of fetch request
+// Storage represetns internal storage of fetch request. Response bodies
+// are stored compressed with Codec (gzip by default) and deduplicated
+// across all fetches by content hash.
 type Storage struct { // Implements RepositoryAdder interface
-	uid  int
-	db   map[int][]response
-	init sync.Once // for mutual exlcusion of critical section
+	Codec string
+
+	uid        int
+	db         map[key][]response
+	blobs      map[[32]byte]*blob
+	series     map[key]map[string][]point // extracted values by fetch and name
+	deduped    int                        // bodies deduplicated since last compaction
+	dedupSaved int                        // bytes saved by dedup since last compaction
+	mu         sync.RWMutex
+	init       sync.Once // for mutual exlcusion of critical section
+}
+
+func (s *Storage) initOnce() {
+	s.init.Do(func() {
+		s.uid = 0
+		s.db = make(map[key][]response)
+		s.blobs = make(map[[32]byte]*blob)
+		s.series = make(map[key]map[string][]point)
+	})
 }
 
 // CreateRecord provides adding record funcionality into response storge
 // for each fetch request.
-func (s *Storage) CreateRecord(data responding.Response) responding.ServiceValidation {
+func (s *Storage) CreateRecord(data responding.Response) (int, error) {
 
 	// Init once
-	s.init.Do(func() {
-		s.uid = 0
-		s.db = make(map[int][]response)
-	})
+	s.initOnce()
+
+	content := []byte(data.Content)
+	if data.Data != nil {
+		content = data.Data
+	}
 
+	now := timeutil.TimestampNow()
 	record := response{
-		response:  data.Content,
-		duration:  data.Duration,
-		createdAt: fmt.Sprintf("%.5f", timeutil.TimestampNow().Float64()),
+		body:       sha256.Sum256(content),
+		binary:     data.Data != nil,
+		mediaType:  data.MediaType,
+		duration:   data.Duration,
+		delay:      data.Delay,
+		createdAt:  fmt.Sprintf("%.5f", now.Float64()),
+		outcome:    listing.Outcome(data),
+		err:        data.Err,
+		manual:     data.Manual,
+		assertions: data.Assertions,
+	}
+
+	// New body is compressed unlocked, so that it does not hold back
+	// other records, and the lock is taken again to insert it.
+	var fresh *blob
+	for {
+		s.mu.Lock()
+		if _, ok := s.blobs[record.body]; ok || fresh != nil {
+			break
+		}
+		s.mu.Unlock()
+
+		b, err := s.newBlob(content)
+		if err != nil {
+			return -1, fmt.Errorf("compressing response body: %w", err)
+		}
+		fresh = b
+	}
+	defer s.mu.Unlock()
+
+	if b, ok := s.blobs[record.body]; ok { // known or stored meanwhile
+		b.refs++
+		s.deduped++
+		s.dedupSaved += len(b.data)
+	} else {
+		s.blobs[record.body] = fresh
 	}
 
-	key := data.StorageKeyID
-	s.db[key] = append(s.db[key], record)
-	fmt.Println(s.db[key]) // temp for content check
 	s.uid++
-	return responding.ServiceValidation{StorageKeyID: s.uid, Status: http.StatusOK, Msg: "Record has been insert into response db."}
+	record.seq = s.uid
+
+	k := key{tenant: data.Tenant, id: data.StorageKeyID}
+	s.db[k] = append(s.db[k], record)
+
+	for _, sample := range data.Samples {
+		if s.series[k] == nil {
+			s.series[k] = make(map[string][]point)
+		}
+		s.series[k][sample.Name] = append(s.series[k][sample.Name], point{at: now.Time, sample: sample})
+	}
+
+	return s.uid, nil
+}
+
+// newBlob compresses content with storage codec. Content is kept
+// uncompressed if compression does not make it smaller.
+func (s *Storage) newBlob(content []byte) (*blob, error) {
+	if s.Codec == Plain {
+		return &blob{data: content, codec: Plain, refs: 1}, nil
+	}
+
+	data, err := compress(s.Codec, content)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) >= len(content) {
+		return &blob{data: content, codec: Plain, refs: 1}, nil
+	}
+	return &blob{data: data, codec: s.Codec, refs: 1}, nil
+}
+
+// History returns decompressed page of response history of tenant
+// fetch with given ID. Records are kept in creation order, so page
+// sorted by creation is found by binary search and only its bodies are
+// decompressed. Reports false if fetch has no responses.
+func (s *Storage) History(tenant, id string, q listing.HistoryQuery) ([]listing.Response, bool) {
+	s.initOnce()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records, ok := s.db[key{tenant: tenant, id: id}]
+	if !ok {
+		return nil, false
+	}
+
+	var page []response
+	if q.Sort == listing.SortDuration {
+		page = pageByDuration(records, q)
+	} else {
+		page = pageByCreation(records, q)
+	}
+
+	history := make([]listing.Response, 0, len(page))
+	for _, r := range page {
+		b := s.blobs[r.body]
+		content, err := decompress(b.codec, b.data)
+		if err != nil {
+			log.Printf("Decompressing response body failed: %v\n", err)
+			continue
+		}
+		createdAt, _ := strconv.ParseFloat(r.createdAt, 64)
+		item := listing.Response{
+			Seq:        r.seq,
+			MediaType:  r.mediaType,
+			Duration:   r.duration,
+			Delay:      r.delay,
+			CreatedAt:  createdAt,
+			Outcome:    r.outcome,
+			Err:        r.err,
+			Manual:     r.manual,
+			Assertions: r.assertions,
+		}
+		if r.binary {
+			item.Data = content
+		} else {
+			item.Content = string(content)
+		}
+		history = append(history, item)
+	}
+	return history, true
+}
+
+// bounds returns index range [start, end) of records created within
+// query time range. Records must be sorted by creation.
+func bounds(records []response, q listing.HistoryQuery) (int, int) {
+	start, end := 0, len(records)
+	if !q.From.IsZero() {
+		start = sort.Search(len(records), func(i int) bool { return !records[i].time().Before(q.From) })
+	}
+	if !q.To.IsZero() {
+		end = sort.Search(len(records), func(i int) bool { return records[i].time().After(q.To) })
+	}
+	return start, end
+}
+
+// pageByCreation returns at most q.Limit records matching q in
+// creation order starting after q.After.
+func pageByCreation(records []response, q listing.HistoryQuery) []response {
+	start, end := bounds(records, q)
+	if q.After != nil && q.Desc { // seq grows with creation
+		if i := sort.Search(len(records), func(i int) bool { return records[i].seq >= q.After.Seq }); i < end {
+			end = i
+		}
+	} else if q.After != nil {
+		if i := sort.Search(len(records), func(i int) bool { return records[i].seq > q.After.Seq }); i > start {
+			start = i
+		}
+	}
+
+	page := []response{}
+	for n := 0; n < end-start && len(page) < q.Limit; n++ {
+		i := start + n
+		if q.Desc {
+			i = end - 1 - n
+		}
+		if q.Outcome == "" || records[i].outcome == q.Outcome {
+			page = append(page, records[i])
+		}
+	}
+	return page
+}
+
+// pageByDuration returns at most q.Limit records matching q sorted by
+// duration starting after q.After. Only matching records are sorted.
+func pageByDuration(records []response, q listing.HistoryQuery) []response {
+	less := func(a, b response) bool {
+		if a.duration != b.duration {
+			return a.duration < b.duration
+		}
+		return a.seq < b.seq
+	}
+	if q.Desc {
+		asc := less
+		less = func(a, b response) bool { return asc(b, a) }
+	}
+
+	start, end := bounds(records, q)
+	page := []response{}
+	for _, r := range records[start:end] {
+		if q.Outcome != "" && r.outcome != q.Outcome {
+			continue
+		}
+		if q.After != nil && !less(response{duration: q.After.Value, seq: q.After.Seq}, r) {
+			continue
+		}
+		page = append(page, r)
+	}
+
+	sort.Slice(page, func(i, j int) bool { return less(page[i], page[j]) })
+	if len(page) > q.Limit {
+		page = page[:q.Limit]
+	}
+	return page
+}
+
+// Series returns values of named time series of tenant fetch with given
+// ID created within [from, to] time range. Series has the type of its
+// latest point, points of other type extracted before the extractor
+// type was changed are skipped. Reports false if fetch has no series
+// with such name.
+func (s *Storage) Series(tenant, id, name string, from, to time.Time) (listing.Series, bool) {
+	s.initOnce()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	points, ok := s.series[key{tenant: tenant, id: id}][name]
+	if !ok {
+		return listing.Series{}, false
+	}
+
+	series := listing.Series{Name: name, Type: points[len(points)-1].sample.Type, Points: []listing.Point{}}
+	for _, p := range points {
+		if p.sample.Type != series.Type || p.at.Before(from) || p.at.After(to) {
+			continue
+		}
+		series.Points = append(series.Points, listing.Point{At: p.at, Value: p.value()})
+	}
+	return series, true
 }
@@ -0,0 +1,97 @@
+package response_test
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gobuzz/pkg/domain/responding"
+	. "github.com/gobuzz/pkg/storage/memory/response"
+)
+
+// Workload: 50 fetches, 200 ticks each. Half of fetches return the same
+// body every time (e.g. httpbin.org/range), the other half return JSON
+// which changes on every tick.
+const (
+	benchFetches = 50
+	benchTicks   = 200
+)
+
+// benchBody returns response body of fetch id at given tick.
+func benchBody(id, tick int) string {
+	if id%2 == 0 {
+		return strings.Repeat("abcdefghijklmnopqrstuvwxyz", 40) // ~1KB static body
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `{"fetch":%d,"tick":%d,"items":[`, id, tick)
+	for i := 0; i < 40; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id":%d,"name":"item-%d","price":%d.%02d,"available":%t}`, i, i, (i*tick)%100, i%100, i%3 == 0)
+	}
+	b.WriteString("]}")
+	return b.String()
+}
+
+// heapInUse returns bytes of live heap objects after garbage collection.
+func heapInUse() uint64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// benchStorage reports heap used by storage filled with workload.
+// newStorage returns fill func adding body into a new storage and
+// the storage itself which is kept alive until heap is measured.
+func benchStorage(b *testing.B, newStorage func() (func(id int, body string), interface{})) {
+	bodies := make([][]string, benchTicks)
+	for tick := range bodies {
+		for id := 0; id < benchFetches; id++ {
+			bodies[tick] = append(bodies[tick], benchBody(id, tick))
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var heap uint64
+	for n := 0; n < b.N; n++ {
+		before := heapInUse()
+		fill, storage := newStorage()
+		for tick := range bodies {
+			for id, body := range bodies[tick] {
+				fill(id, string([]byte(body))) // fresh copy as read from network
+			}
+		}
+		heap += heapInUse() - before
+		runtime.KeepAlive(storage)
+	}
+	b.ReportMetric(float64(heap)/float64(b.N), "heap-B/op")
+}
+
+// BenchmarkStorageUncompressed models previous storage keeping each
+// body as plain string per record.
+func BenchmarkStorageUncompressed(b *testing.B) {
+	benchStorage(b, func() (func(id int, body string), interface{}) {
+		db := make(map[int][]string)
+		return func(id int, body string) { db[id] = append(db[id], body) }, db
+	})
+}
+
+// BenchmarkStorage measures compressed, deduplicated storage for each codec.
+func BenchmarkStorage(b *testing.B) {
+	for _, codec := range []string{Plain, Gzip, Zstd} {
+		b.Run(codec, func(b *testing.B) {
+			benchStorage(b, func() (func(id int, body string), interface{}) {
+				storage := &Storage{Codec: codec}
+				return func(id int, body string) {
+					storage.CreateRecord(responding.Response{StorageKeyID: strconv.Itoa(id), Content: body})
+				}, storage
+			})
+		})
+	}
+}
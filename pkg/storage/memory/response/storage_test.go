@@ -0,0 +1,229 @@
+package response_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gobuzz/pkg/domain/compacting"
+	"github.com/gobuzz/pkg/domain/listing"
+	"github.com/gobuzz/pkg/domain/responding"
+	. "github.com/gobuzz/pkg/storage/memory/response"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Response storage", func() {
+	all := listing.HistoryQuery{Page: listing.Page{Limit: listing.MaxLimit}, Sort: listing.SortCreated}
+
+	Describe("When calling Compact", func() {
+		var (
+			storage *Storage
+			noLimit func(tenant, id string) compacting.Policy
+		)
+
+		BeforeEach(func() { // Configuration
+			storage = &Storage{Codec: Plain}
+			noLimit = func(tenant, id string) compacting.Policy { return compacting.Policy{} }
+			for i := 0; i < 4; i++ { // unchanged body
+				storage.CreateRecord(responding.Response{StorageKeyID: "0", Content: strings.Repeat("a", 100)})
+			}
+			time.Sleep(time.Millisecond)
+			for _, c := range []string{"b", "c", "d"} { // changing body
+				storage.CreateRecord(responding.Response{StorageKeyID: "1", Content: strings.Repeat(c, 10), Samples: []responding.Sample{{Name: "v", Type: "number"}}})
+			}
+		})
+
+		Context("When there are no limits.", func() {
+			It("Should only report deduplicated bodies.", func() {
+				report := storage.Compact(noLimit, compacting.Policy{}, time.Now())
+				Expect(report.DedupedBodies).To(Equal(3))
+				Expect(report.RemovedRecords).To(Equal(0))
+				Expect(report.ReclaimedBytes).To(Equal(300))
+				Expect(report.StoredBytes).To(Equal(130))
+
+				report = storage.Compact(noLimit, compacting.Policy{}, time.Now())
+				Expect(report.DedupedBodies).To(Equal(0))
+				Expect(report.ReclaimedBytes).To(Equal(0))
+			})
+		})
+
+		Context("When fetch policy limits records.", func() {
+			It("Should keep last N records of each fetch.", func() {
+				policy := func(tenant, id string) compacting.Policy { return compacting.Policy{MaxRecords: 2} }
+				report := storage.Compact(policy, compacting.Policy{}, time.Now())
+				Expect(report.RemovedRecords).To(Equal(3))
+				Expect(report.RemovedPoints).To(Equal(1))
+				Expect(report.StoredBytes).To(Equal(120)) // shared body stored once
+			})
+
+			It("Should remove records older than max age.", func() {
+				policy := func(tenant, id string) compacting.Policy { return compacting.Policy{MaxAge: time.Minute} }
+				report := storage.Compact(policy, compacting.Policy{}, time.Now().Add(time.Hour))
+				Expect(report.RemovedRecords).To(Equal(7))
+				Expect(report.RemovedPoints).To(Equal(3))
+				Expect(report.StoredBytes).To(Equal(0))
+				_, ok := storage.Series("", "1", "v", time.Time{}, time.Now().Add(time.Hour))
+				Expect(ok).To(BeFalse())
+			})
+
+			It("Should remove oldest records until bytes limit is met.", func() {
+				policy := func(tenant, id string) compacting.Policy {
+					if id == "1" {
+						return compacting.Policy{MaxBytes: 15}
+					}
+					return compacting.Policy{}
+				}
+				report := storage.Compact(policy, compacting.Policy{}, time.Now())
+				Expect(report.RemovedRecords).To(Equal(2))
+				Expect(report.StoredBytes).To(Equal(110))
+			})
+		})
+
+		Context("When global bytes limit is set.", func() {
+			It("Should remove oldest records across all fetches.", func() {
+				report := storage.Compact(noLimit, compacting.Policy{MaxBytes: 20}, time.Now())
+				Expect(report.RemovedRecords).To(Equal(5))
+				Expect(report.StoredBytes).To(Equal(20))
+			})
+		})
+	})
+
+	Describe("When calling History", func() {
+		It("Should return decompressed bodies for each codec.", func() {
+			content := strings.Repeat(`{"id":1,"name":"gopher"}`, 50)
+			for _, codec := range []string{Gzip, Zstd, Plain} {
+				storage := &Storage{Codec: codec}
+				storage.CreateRecord(responding.Response{StorageKeyID: "3", Content: content, Duration: 0.25})
+				storage.CreateRecord(responding.Response{StorageKeyID: "3", Content: "null"})
+
+				history, ok := storage.History("", "3", all)
+				Expect(ok).To(BeTrue())
+				Expect(history).To(HaveLen(2))
+				Expect(history[0].Content).To(Equal(content))
+				Expect(history[0].Duration).To(Equal(0.25))
+				Expect(history[1].Content).To(Equal("null"))
+
+				report := storage.Compact(func(tenant, id string) compacting.Policy { return compacting.Policy{} }, compacting.Policy{}, time.Now())
+				if codec != Plain {
+					Expect(report.StoredBytes).To(BeNumerically("<", len(content)))
+				}
+			}
+		})
+
+		It("Should return binary bodies as bytes with media type.", func() {
+			data := []byte{0x89, 0x50, 0x4e, 0x47, 0x00, 0xff}
+			storage := new(Storage)
+			storage.CreateRecord(responding.Response{StorageKeyID: "0", Data: data, MediaType: "image/png"})
+
+			history, _ := storage.History("", "0", all)
+			Expect(history[0].Content).To(BeEmpty())
+			Expect(history[0].Data).To(Equal(data))
+			Expect(history[0].MediaType).To(Equal("image/png"))
+		})
+
+		It("Should keep histories of tenants apart.", func() {
+			storage := new(Storage)
+			storage.CreateRecord(responding.Response{StorageKeyID: "0", Tenant: "team-a", Content: "a"})
+			storage.CreateRecord(responding.Response{StorageKeyID: "0", Tenant: "team-b", Content: "b"})
+
+			history, ok := storage.History("team-a", "0", all)
+			Expect(ok).To(BeTrue())
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Content).To(Equal("a"))
+			_, ok = storage.History("team-c", "0", all)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("Should report false for unknown fetch.", func() {
+			_, ok := new(Storage).History("", "1", all)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("When calling History with query", func() {
+		var (
+			storage *Storage
+			seqs    func(history []listing.Response) []int
+		)
+
+		BeforeEach(func() { // Configuration
+			storage = new(Storage)
+			seqs = func(history []listing.Response) []int {
+				ids := []int{}
+				for _, h := range history {
+					ids = append(ids, h.Seq)
+				}
+				return ids
+			}
+			durations := []float64{0.3, 0.1, 0.5, 0.1, 0.2}
+			for i, d := range durations {
+				r := responding.Response{StorageKeyID: "0", Content: strings.Repeat("a", i+1), Duration: d}
+				if i == 2 {
+					r.Content, r.Err = "null", "Not Found"
+				}
+				storage.CreateRecord(r)
+			}
+		})
+
+		It("Should return pages in creation order.", func() {
+			q := listing.HistoryQuery{Page: listing.Page{Limit: 2}, Sort: listing.SortCreated}
+			history, _ := storage.History("", "0", q)
+			Expect(seqs(history)).To(Equal([]int{1, 2}))
+
+			q.After = &listing.Position{Seq: 2}
+			history, _ = storage.History("", "0", q)
+			Expect(seqs(history)).To(Equal([]int{3, 4}))
+
+			q.Desc, q.After = true, nil
+			history, _ = storage.History("", "0", q)
+			Expect(seqs(history)).To(Equal([]int{5, 4}))
+
+			q.After = &listing.Position{Seq: 4}
+			history, _ = storage.History("", "0", q)
+			Expect(seqs(history)).To(Equal([]int{3, 2}))
+		})
+
+		It("Should return pages in duration order.", func() {
+			q := listing.HistoryQuery{Page: listing.Page{Limit: 3}, Sort: listing.SortDuration}
+			history, _ := storage.History("", "0", q)
+			Expect(seqs(history)).To(Equal([]int{2, 4, 5}))
+
+			q.After = &listing.Position{Value: 0.2, Seq: 5}
+			history, _ = storage.History("", "0", q)
+			Expect(seqs(history)).To(Equal([]int{1, 3}))
+
+			q.Desc, q.After = true, &listing.Position{Value: 0.3, Seq: 1}
+			history, _ = storage.History("", "0", q)
+			Expect(seqs(history)).To(Equal([]int{5, 4, 2}))
+		})
+
+		It("Should filter by outcome and time range.", func() {
+			q := listing.HistoryQuery{Page: listing.Page{Limit: 10}, Sort: listing.SortCreated, Outcome: listing.OutcomeError}
+			history, _ := storage.History("", "0", q)
+			Expect(seqs(history)).To(Equal([]int{3}))
+			Expect(history[0].Err).To(Equal("Not Found"))
+
+			q = listing.HistoryQuery{Page: listing.Page{Limit: 10}, Sort: listing.SortCreated, To: time.Now().Add(-time.Hour)}
+			history, _ = storage.History("", "0", q)
+			Expect(history).To(BeEmpty())
+
+			q = listing.HistoryQuery{Page: listing.Page{Limit: 10}, Sort: listing.SortDuration, From: time.Now().Add(-time.Hour)}
+			history, _ = storage.History("", "0", q)
+			Expect(history).To(HaveLen(5))
+		})
+	})
+
+	Describe("When calling Series", func() {
+		It("Should skip points extracted with previous type.", func() {
+			storage := new(Storage)
+			storage.CreateRecord(responding.Response{StorageKeyID: "0", Samples: []responding.Sample{{Name: "v", Type: "number", Number: 1}}})
+			storage.CreateRecord(responding.Response{StorageKeyID: "0", Samples: []responding.Sample{{Name: "v", Type: "string", Text: "up"}}})
+
+			series, ok := storage.Series("", "0", "v", time.Time{}, time.Now())
+			Expect(ok).To(BeTrue())
+			Expect(series.Type).To(Equal("string"))
+			Expect(series.Points).To(HaveLen(1))
+			Expect(series.Points[0].Value).To(Equal("up"))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+package tenant
+
+import (
+	"sync"
+
+	"github.com/gobuzz/pkg/domain/adding"
+)
+
+// Storage represetns internal storage of tenant quotas. Tenants without
+// their own quota share Default, Global limits apply to all tenants.
+type Storage struct {
+	Default adding.Quota
+	Global  adding.Limits
+
+	db   map[string]adding.Quota
+	mu   sync.RWMutex
+	init sync.Once // for mutual exlcusion of critical section
+}
+
+func (s *Storage) initOnce() {
+	s.init.Do(func() {
+		s.db = make(map[string]adding.Quota)
+	})
+}
+
+// Quota returns quota of the tenant.
+func (s *Storage) Quota(tenant string) adding.Quota {
+	s.initOnce()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if q, ok := s.db[tenant]; ok {
+		return q
+	}
+	return s.Default
+}
+
+// SetQuota replaces quota of the tenant.
+func (s *Storage) SetQuota(tenant string, quota adding.Quota) {
+	s.initOnce()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.db[tenant] = quota
+}
+
+// Limits returns server wide limits.
+func (s *Storage) Limits() adding.Limits {
+	return s.Global
+}
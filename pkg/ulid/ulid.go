@@ -0,0 +1,98 @@
+// Package ulid generates ULIDs, 26 characters long identifiers unique
+// across processes and sortable by creation time.
+package ulid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+)
+
+// alphabet is Crockford's base32.
+const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Generator makes ULIDs of millisecond time and random bits read from
+// entropy. ULIDs made within the same millisecond increment random bits
+// of the previous one, so they are ordered too.
+type Generator struct {
+	entropy io.Reader
+	mu      sync.Mutex
+	ms      uint64
+	random  [10]byte
+}
+
+// NewGenerator returns generator reading random bits from entropy.
+func NewGenerator(entropy io.Reader) *Generator {
+	return &Generator{entropy: entropy}
+}
+
+var std = NewGenerator(rand.Reader)
+
+// New returns ULID of current time.
+func New() string {
+	return std.Make(time.Now())
+}
+
+// Make returns ULID of time t.
+func (g *Generator) Make(t time.Time) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	if ms > g.ms {
+		g.ms = ms
+		if _, err := io.ReadFull(g.entropy, g.random[:]); err != nil {
+			panic("ulid: reading entropy: " + err.Error())
+		}
+	} else { // same or earlier millisecond keeps the order
+		for i := len(g.random) - 1; i >= 0; i-- {
+			g.random[i]++
+			if g.random[i] != 0 {
+				break
+			}
+		}
+	}
+
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], g.ms<<16)
+	copy(b[6:], g.random[:])
+	return encode(b)
+}
+
+// encode writes 128 bits as 26 base32 characters, the first one
+// carries 3 bits only.
+func encode(b [16]byte) string {
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var s [26]byte
+	for i := len(s) - 1; i >= 0; i-- {
+		s[i] = alphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(s[:])
+}
+
+// Valid reports whether s is a ULID.
+func Valid(s string) bool {
+	if len(s) != 26 || s[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !validChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// validChar reports whether c belongs to alphabet.
+func validChar(c byte) bool {
+	for i := 0; i < len(alphabet); i++ {
+		if alphabet[i] == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package ulid_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestUlid(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ulid Suite")
+}
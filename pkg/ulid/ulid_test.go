@@ -0,0 +1,54 @@
+package ulid_test
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	. "github.com/gobuzz/pkg/ulid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ULID", func() {
+
+	Describe("When calling Make", func() {
+		It("Should encode time and random bits.", func() {
+			g := NewGenerator(bytes.NewReader(bytes.Repeat([]byte{0xff}, 10)))
+			id := g.Make(time.Unix(1469918176, 385000000))
+			Expect(id).To(Equal("01ARYZ6S41ZZZZZZZZZZZZZZZZ"))
+			Expect(Valid(id)).To(BeTrue())
+		})
+
+		It("Should keep order within the same millisecond.", func() {
+			g := NewGenerator(bytes.NewReader(make([]byte, 10)))
+			now := time.Now()
+			first, second := g.Make(now), g.Make(now)
+			Expect(first < second).To(BeTrue())
+			Expect(second[len(second)-1:]).To(Equal("1"))
+		})
+	})
+
+	Describe("When calling New", func() {
+		It("Should return unique IDs sorted by creation.", func() {
+			ids := make([]string, 1000)
+			seen := make(map[string]bool)
+			for i := range ids {
+				ids[i] = New()
+				seen[ids[i]] = true
+			}
+			Expect(seen).To(HaveLen(len(ids)))
+			Expect(sort.StringsAreSorted(ids)).To(BeTrue())
+		})
+	})
+
+	Describe("When calling Valid", func() {
+		It("Should reject malformed IDs.", func() {
+			Expect(Valid("01ARYZ6S41TSV4RRFFQ69G5FAV")).To(BeTrue())
+			Expect(Valid("01arYZ6S41TSV4RRFFQ69G5FAV")).To(BeFalse())
+			Expect(Valid("81ARYZ6S41TSV4RRFFQ69G5FAV")).To(BeFalse())
+			Expect(Valid("01ARYZ6S41TSV4RRFFQ69G5FA")).To(BeFalse())
+			Expect(Valid("front")).To(BeFalse())
+		})
+	})
+})